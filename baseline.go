@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/zero-day-ai/sdk/agent"
+
+	"github.com/zero-day-ai/agents/debug/internal/runner"
+)
+
+// baselineTimeout bounds loading and saving a baseline
+const baselineTimeout = 30 * time.Second
+
+// newBaselineStore creates the baseline store selected by the configuration
+func newBaselineStore(h agent.Harness, cfg *DebugConfig) (runner.BaselineStore, error) {
+	switch cfg.BaselineStorage {
+	case BaselineFile:
+		return runner.NewFileBaselineStore(cfg.BaselinePath), nil
+	case BaselineMission, BaselineLongTerm:
+		mem := h.Memory()
+		if mem == nil {
+			return nil, fmt.Errorf("memory not available for %s baselines", cfg.BaselineStorage)
+		}
+		if cfg.BaselineStorage == BaselineMission {
+			if mem.Mission() == nil {
+				return nil, fmt.Errorf("mission memory not available")
+			}
+			return runner.NewMissionBaselineStore(mem.Mission()), nil
+		}
+		if mem.LongTerm() == nil {
+			return nil, fmt.Errorf("long-term memory not available")
+		}
+		return runner.NewLongTermBaselineStore(mem.LongTerm()), nil
+	default:
+		return nil, fmt.Errorf("invalid baseline_storage: %s", cfg.BaselineStorage)
+	}
+}
+
+// compareBaseline compares the suite against the configured baseline and then
+// saves the suite as a new baseline. It returns nil when baselines are disabled
+// or no baseline exists yet. Baseline problems are logged and never fail the run.
+func compareBaseline(ctx context.Context, h agent.Harness, cfg *DebugConfig, suiteResult *runner.SuiteResult) *runner.Comparison {
+	if cfg.BaselineStorage == BaselineDisabled {
+		return nil
+	}
+
+	logger := h.Logger()
+	store, err := newBaselineStore(h, cfg)
+	if err != nil {
+		logger.Warn("Baseline comparison unavailable",
+			"error", err,
+		)
+		return nil
+	}
+
+	// The suite context may have expired; baselines are still worth keeping
+	baselineCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), baselineTimeout)
+	defer cancel()
+
+	var comparison *runner.Comparison
+	baseline, err := store.Load(baselineCtx, cfg.BaselineName)
+	switch {
+	case errors.Is(err, runner.ErrBaselineNotFound):
+		logger.Info("No baseline to compare against",
+			"baseline", cfg.BaselineName,
+			"storage", cfg.BaselineStorage,
+		)
+	case err != nil:
+		logger.Warn("Failed to load baseline",
+			"baseline", cfg.BaselineName,
+			"error", err,
+		)
+	default:
+		comparison = runner.Compare(baseline, suiteResult, runner.CompareOptions{
+			DurationThreshold: cfg.RegressionThreshold,
+			MinDurationDelta:  runner.DefaultCompareOptions.MinDurationDelta,
+		})
+		logger.Info("Compared against baseline",
+			"baseline", cfg.BaselineName,
+			"baseline_created_at", baseline.CreatedAt,
+			"newly_failing", len(comparison.NewlyFailing),
+			"newly_passing", len(comparison.NewlyPassing),
+			"disappeared", len(comparison.Disappeared),
+			"duration_regressions", len(comparison.DurationRegressions),
+		)
+	}
+
+	if cfg.SaveBaseline != "" {
+		if err := store.Save(baselineCtx, runner.NewBaseline(cfg.SaveBaseline, suiteResult)); err != nil {
+			logger.Warn("Failed to save baseline",
+				"baseline", cfg.SaveBaseline,
+				"error", err,
+			)
+		} else {
+			logger.Info("Saved baseline",
+				"baseline", cfg.SaveBaseline,
+				"storage", cfg.BaselineStorage,
+			)
+		}
+	}
+
+	return comparison
+}
+
+// formatComparisonText renders a baseline comparison for the text report
+func formatComparisonText(comparison *runner.Comparison) string {
+	output := fmt.Sprintf("\n=== Baseline Comparison ===\nBaseline: %s (%s)\n%s\n",
+		comparison.BaselineName,
+		comparison.BaselineCreatedAt.Format(time.RFC3339),
+		comparison.Summary(),
+	)
+
+	for _, change := range comparison.NewlyFailing {
+		output += fmt.Sprintf("  Newly failing: %s (%s -> %s)\n", change.ID, change.Baseline, change.Current)
+	}
+	for _, change := range comparison.NewlyPassing {
+		output += fmt.Sprintf("  Newly passing: %s (%s -> %s)\n", change.ID, change.Baseline, change.Current)
+	}
+	for _, test := range comparison.Disappeared {
+		output += fmt.Sprintf("  Disappeared: %s (was %s)\n", test.ID, test.Status)
+	}
+	for _, change := range comparison.DurationRegressions {
+		output += fmt.Sprintf("  Slower: %s (%s -> %s)\n", change.ID,
+			change.Baseline.Round(time.Millisecond), change.Current.Round(time.Millisecond))
+	}
+
+	return output
+}
@@ -2,6 +2,9 @@ package main
 
 import (
 	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strconv"
 	"time"
 
 	"github.com/zero-day-ai/sdk/agent"
@@ -19,40 +22,124 @@ const (
 
 	// OutputBoth produces both JSON and text output
 	OutputBoth OutputFormat = "both"
+
+	// OutputJUnit produces a JUnit XML report
+	OutputJUnit OutputFormat = "junit"
+)
+
+// BaselineStorage defines where run baselines are kept
+type BaselineStorage string
+
+const (
+	// BaselineDisabled disables saving and comparing baselines
+	BaselineDisabled BaselineStorage = ""
+
+	// BaselineFile keeps baselines as JSON files in BaselinePath
+	BaselineFile BaselineStorage = "file"
+
+	// BaselineMission keeps baselines in mission memory
+	BaselineMission BaselineStorage = "mission"
+
+	// BaselineLongTerm keeps baselines in long-term memory, shared across missions
+	BaselineLongTerm BaselineStorage = "long_term"
 )
 
 // DebugConfig holds configuration for debug agent execution
 type DebugConfig struct {
+	// Profile is the named set of defaults the configuration starts from
+	// Built-in profiles: "smoke", "full" (DefaultConfig) and "nightly"
+	Profile string
+
 	// Verbose enables detailed output during execution
 	Verbose bool
 
-	// TargetTests specifies which test modules to run in single mode
-	// Example: ["llm-integration", "daemon-service"]
+	// TargetTests restricts execution to the listed modules, tests, test IDs, requirement IDs or tags
+	// Example: ["network-recon", "GraphRAG Storage", "NR-6"]
 	TargetTests []string
 
 	// Timeout is the overall execution timeout
 	Timeout time.Duration
 
 	// CategoryTimeout is the timeout per test category
+	// The clock for a category starts when its first module starts
 	CategoryTimeout time.Duration
 
 	// TestTimeout is the timeout per individual test
 	TestTimeout time.Duration
 
-	// SkipCategories lists test categories to skip
+	// Concurrency is the maximum number of test modules executed at the same time
+	// Modules that declare dependencies always wait for them regardless of this value
+	Concurrency int
+
+	// RetryAttempts is the default number of attempts per test (1 disables retries)
+	// Tests that declare their own retry policy keep it
+	RetryAttempts int
+
+	// RetryBackoff is the delay before the first retry, doubled on each further retry
+	RetryBackoff time.Duration
+
+	// SkipCategories lists test categories to skip ("sdk", "framework")
 	SkipCategories []string
 
-	// SkipTests lists individual tests to skip by name
+	// SkipTests lists modules, tests, test IDs, requirement IDs or tags to skip
 	SkipTests []string
 
-	// OutputFormat determines report output format (json, text, both)
+	// OutputFormat determines report output format (json, text, both, junit)
 	OutputFormat OutputFormat
 
 	// SubmitFindings determines whether to submit failed tests as findings
 	SubmitFindings bool
 
+	// AllowActiveScans allows tests that actively scan the network (ping sweeps,
+	// port scans); when false they and the tests depending on them are skipped
+	AllowActiveScans bool
+
+	// ProgressKey is the working memory key where live suite progress is published
+	// An empty value disables publishing
+	ProgressKey string
+
+	// BaselineStorage is where run baselines are saved and loaded (empty disables baselines)
+	BaselineStorage BaselineStorage
+
+	// BaselinePath is the directory for file baselines
+	BaselinePath string
+
+	// BaselineName is the baseline the run is compared against
+	BaselineName string
+
+	// SaveBaseline is the name under which this run is saved as a baseline
+	// An empty value disables saving
+	SaveBaseline string
+
+	// RegressionThreshold is the relative slowdown reported as a duration regression
+	// (0.5 means 50% slower than the baseline; 0 disables duration checks)
+	RegressionThreshold float64
+
+	// FailOnRegression fails the agent result when the run regressed against the baseline
+	FailOnRegression bool
+
+	// FailFast aborts the remaining tests after the first failure or error
+	// Valid values: "suite", "category", "module"; empty runs every test
+	FailFast string
+
+	// Shuffle runs modules and tests in random order (dependencies still run first)
+	Shuffle bool
+
+	// ShuffleSeed seeds the shuffled order; the seed of a run is printed in its
+	// report and reproduces its order. Setting it enables Shuffle.
+	ShuffleSeed int64
+
+	// DryRun resolves configuration and selection and checks preconditions, then
+	// reports the planned tests without calling any tools or LLMs
+	DryRun bool
+
 	// Debug Mission Context Fields
 
+	// Mode selects what the task runs: a test suite ("full", "sdk", "framework",
+	// "single", "network-recon") or one targeted diagnostic ("health-check",
+	// "memory-test", "llm-test", "child", "summary", ...)
+	Mode Mode
+
 	// Component specifies which component to test in health-check mode
 	// Valid values: "graphrag", "tools", "memory", "plugins"
 	Component string
@@ -64,6 +151,20 @@ type DebugConfig struct {
 	// Prefix is the prefix to use for test data (e.g., "[DEBUG]")
 	Prefix string
 
+	// Framework Configuration
+
+	// DaemonAddress is the gRPC address (host:port) of the Gibson daemon;
+	// GIBSON_DAEMON_ADDRESS is used when empty
+	DaemonAddress string
+
+	// RegistryEndpoints are the etcd endpoints of the component registry;
+	// GIBSON_REGISTRY_ENDPOINTS is used when empty
+	RegistryEndpoints []string
+
+	// RegistryFake runs the registry tests against a partial in-memory etcd
+	// emulation instead of RegistryEndpoints; this only checks the SDK client
+	RegistryFake bool
+
 	// Network Reconnaissance Configuration
 
 	// Subnet is the CIDR subnet to scan (optional, auto-discovered if empty)
@@ -78,33 +179,55 @@ type DebugConfig struct {
 
 	// GenerateIntelligence determines whether to run LLM analysis
 	GenerateIntelligence bool
+
+	// Sources maps each key set by a configuration layer to where its value came
+	// from ("file <path>", "env DEBUG_AGENT_TIMEOUT", "flag --timeout", "context", ...)
+	// Keys not listed keep their default
+	Sources map[string]string
 }
 
-// DefaultConfig returns a DebugConfig with sensible defaults
+// DefaultConfig returns the "full" profile: every test, including active network
+// scans, with sensible defaults
 func DefaultConfig() *DebugConfig {
 	return &DebugConfig{
+		Profile:              defaultProfile,
 		Verbose:              false,
 		TargetTests:          []string{},
-		Timeout:              10 * time.Minute,  // 10 minutes for full suite
-		CategoryTimeout:      60 * time.Second,  // 60 seconds per category
-		TestTimeout:          10 * time.Second,  // 10 seconds per test
+		Timeout:              10 * time.Minute, // 10 minutes for full suite
+		CategoryTimeout:      8 * time.Minute,  // 8 minutes per category (active scans are slow)
+		TestTimeout:          10 * time.Second, // 10 seconds per test
+		Concurrency:          4,                // Up to 4 independent modules at once
+		RetryAttempts:        1,                // No retries unless a test declares its own policy
+		RetryBackoff:         2 * time.Second,
 		SkipCategories:       []string{},
 		SkipTests:            []string{},
 		OutputFormat:         OutputBoth,
 		SubmitFindings:       true,
-		Subnet:               "",       // Auto-discover if empty
+		AllowActiveScans:     true,
+		ProgressKey:          "debug_agent_progress",
+		BaselineStorage:      BaselineDisabled,
+		BaselinePath:         ".debug-agent/baselines",
+		BaselineName:         "latest",
+		SaveBaseline:         "latest",
+		RegressionThreshold:  0.5,
+		FailOnRegression:     false,
+		FailFast:             "",
+		Shuffle:              false,
+		DryRun:               false,
+		Mode:                 ModeFull,
+		Subnet:               "",         // Auto-discover if empty
 		Domains:              []string{}, // Auto-discover from /etc/hosts if empty
 		SkipPhases:           []string{},
 		GenerateIntelligence: true, // Generate LLM analysis by default
+		Sources:              map[string]string{},
 	}
 }
 
 // ParseConfig extracts configuration from the agent task context
 // The task context can contain configuration in either Context or Metadata field
 // Context is used by workflow YAML, Metadata is used by direct API calls
+// Both override the deployment configuration loaded by LoadDeploymentConfig
 func ParseConfig(task agent.Task) (*DebugConfig, error) {
-	cfg := DefaultConfig()
-
 	// DEBUG: Log what we receive
 	fmt.Printf("[DEBUG] ParseConfig: task.ID=%s\n", task.ID)
 	fmt.Printf("[DEBUG] ParseConfig: task.Context keys=%v\n", getConfigMapKeys(task.Context))
@@ -113,13 +236,48 @@ func ParseConfig(task agent.Task) (*DebugConfig, error) {
 		fmt.Printf("[DEBUG] ParseConfig: task.Context[subnet]=%v\n", subnet)
 	}
 
-	// Merge Context and Metadata - Context takes precedence (from workflow YAML)
-	configMap := make(map[string]any)
-	for k, v := range task.Metadata {
-		configMap[k] = v
+	// Task metadata and context override the deployment layers. Context comes
+	// from mission files, so unknown keys there are typos; Metadata may also carry
+	// keys meant for the framework, so only the keys the schema knows are checked.
+	layers := append(slices.Clone(deploymentLayers),
+		configLayer{Source: sourceMetadata, Prefix: "metadata.", Values: task.Metadata},
+		configLayer{Source: sourceContext, Prefix: "context.", Values: task.Context, Strict: true},
+	)
+	return resolveConfig(layers, deploymentProfiles)
+}
+
+// resolveConfig applies the selected profile and then the configuration layers
+// over the defaults, recording the source of every value that is not a default
+func resolveConfig(layers []configLayer, profiles map[string]map[string]any) (*DebugConfig, error) {
+	cfg := DefaultConfig()
+
+	// Check every layer against the config schema before merging
+	var problems []ConfigProblem
+	for _, layer := range layers {
+		problems = append(problems, checkConfigMap(layer.Prefix, layer.Values, layer.Strict)...)
 	}
-	for k, v := range task.Context {
-		configMap[k] = v
+	if len(problems) > 0 {
+		return nil, &ConfigError{Problems: problems}
+	}
+
+	// The profile sits between the defaults and the first layer, so any layer
+	// can override its individual settings
+	profile, err := profileLayer(selectedProfile(layers), profiles)
+	if err != nil {
+		return nil, err
+	}
+	layers = append([]configLayer{profile}, layers...)
+
+	// Merge layers - later layers take precedence
+	configMap := make(map[string]any)
+	for _, layer := range layers {
+		for k, v := range layer.Values {
+			if _, known := lookupConfigField(k); !known {
+				continue
+			}
+			configMap[k] = v
+			cfg.Sources[k] = layer.sourceOf(k)
+		}
 	}
 
 	// Parse configuration from merged map
@@ -131,19 +289,19 @@ func ParseConfig(task agent.Task) (*DebugConfig, error) {
 		return cfg, nil
 	}
 
+	// Parse profile name (its settings were applied as the first layer)
+	if profile, ok := configMap["profile"].(string); ok {
+		cfg.Profile = profile
+	}
+
 	// Parse verbose flag
 	if verbose, ok := configMap["verbose"].(bool); ok {
 		cfg.Verbose = verbose
 	}
 
 	// Parse target tests for single mode
-	if tests, ok := configMap["tests"].([]interface{}); ok {
-		cfg.TargetTests = make([]string, 0, len(tests))
-		for _, test := range tests {
-			if testName, ok := test.(string); ok {
-				cfg.TargetTests = append(cfg.TargetTests, testName)
-			}
-		}
+	if tests, ok := configMap["tests"]; ok {
+		cfg.TargetTests = stringList(tests)
 	}
 
 	// Parse timeout
@@ -173,33 +331,129 @@ func ParseConfig(task agent.Task) (*DebugConfig, error) {
 		}
 	}
 
+	// Parse module concurrency (JSON numbers decode as float64)
+	if concurrency, ok := configMap["concurrency"]; ok {
+		switch v := concurrency.(type) {
+		case float64:
+			cfg.Concurrency = int(v)
+		case int:
+			cfg.Concurrency = v
+		default:
+			return nil, fmt.Errorf("invalid concurrency: %v", concurrency)
+		}
+	}
+
+	// Parse default retry attempts (JSON numbers decode as float64)
+	if attempts, ok := configMap["retry_attempts"]; ok {
+		switch v := attempts.(type) {
+		case float64:
+			cfg.RetryAttempts = int(v)
+		case int:
+			cfg.RetryAttempts = v
+		default:
+			return nil, fmt.Errorf("invalid retry_attempts: %v", attempts)
+		}
+	}
+
+	// Parse retry backoff
+	if backoff, ok := configMap["retry_backoff"].(string); ok {
+		if d, err := time.ParseDuration(backoff); err == nil {
+			cfg.RetryBackoff = d
+		} else {
+			return nil, fmt.Errorf("invalid retry_backoff duration: %s", backoff)
+		}
+	}
+
 	// Parse skip categories
-	if skipCats, ok := configMap["skip_categories"].([]interface{}); ok {
-		cfg.SkipCategories = make([]string, 0, len(skipCats))
-		for _, cat := range skipCats {
-			if catName, ok := cat.(string); ok {
-				cfg.SkipCategories = append(cfg.SkipCategories, catName)
+	if skipCats, ok := configMap["skip_categories"]; ok {
+		cfg.SkipCategories = stringList(skipCats)
+	}
+
+	// Parse skip tests
+	if skipTests, ok := configMap["skip_tests"]; ok {
+		cfg.SkipTests = stringList(skipTests)
+	}
+
+	// Parse baseline settings
+	if storage, ok := configMap["baseline_storage"].(string); ok {
+		cfg.BaselineStorage = BaselineStorage(storage)
+	}
+	if path, ok := configMap["baseline_path"].(string); ok {
+		cfg.BaselinePath = path
+	}
+	if name, ok := configMap["baseline_name"].(string); ok {
+		cfg.BaselineName = name
+	}
+	if name, ok := configMap["save_baseline"].(string); ok {
+		cfg.SaveBaseline = name
+	}
+	if threshold, ok := configMap["regression_threshold"]; ok {
+		switch v := threshold.(type) {
+		case float64:
+			cfg.RegressionThreshold = v
+		case int:
+			cfg.RegressionThreshold = float64(v)
+		default:
+			return nil, fmt.Errorf("invalid regression_threshold: %v", threshold)
+		}
+	}
+	if failOnRegression, ok := configMap["fail_on_regression"].(bool); ok {
+		cfg.FailOnRegression = failOnRegression
+	}
+
+	// Parse fail-fast scope (true is shorthand for the whole suite)
+	if failFast, ok := configMap["fail_fast"]; ok {
+		switch v := failFast.(type) {
+		case string:
+			cfg.FailFast = v
+		case bool:
+			cfg.FailFast = ""
+			if v {
+				cfg.FailFast = "suite"
 			}
+		default:
+			return nil, fmt.Errorf("invalid fail_fast: %v", failFast)
 		}
 	}
 
-	// Parse skip tests
-	if skipTests, ok := configMap["skip_tests"].([]interface{}); ok {
-		cfg.SkipTests = make([]string, 0, len(skipTests))
-		for _, test := range skipTests {
-			if testName, ok := test.(string); ok {
-				cfg.SkipTests = append(cfg.SkipTests, testName)
+	// Parse shuffle settings; without a seed a random one is chosen, small enough to
+	// survive a JSON round trip when passed back to reproduce the run
+	if shuffle, ok := configMap["shuffle"].(bool); ok {
+		cfg.Shuffle = shuffle
+	}
+	if seed, ok := configMap["shuffle_seed"]; ok {
+		switch v := seed.(type) {
+		case float64:
+			cfg.ShuffleSeed = int64(v)
+		case int:
+			cfg.ShuffleSeed = int64(v)
+		case string:
+			parsed, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid shuffle_seed: %s", v)
 			}
+			cfg.ShuffleSeed = parsed
+		default:
+			return nil, fmt.Errorf("invalid shuffle_seed: %v", seed)
 		}
+		cfg.Shuffle = true
+	} else if cfg.Shuffle {
+		cfg.ShuffleSeed = rand.Int64N(1 << 31)
+		cfg.Sources["shuffle_seed"] = sourceGenerated
+	}
+
+	// Parse dry run flag
+	if dryRun, ok := configMap["dry_run"].(bool); ok {
+		cfg.DryRun = dryRun
 	}
 
 	// Parse output format
 	if format, ok := configMap["output_format"].(string); ok {
 		switch OutputFormat(format) {
-		case OutputJSON, OutputText, OutputBoth:
+		case OutputJSON, OutputText, OutputBoth, OutputJUnit:
 			cfg.OutputFormat = OutputFormat(format)
 		default:
-			return nil, fmt.Errorf("invalid output format: %s (must be json, text, or both)", format)
+			return nil, fmt.Errorf("invalid output format: %s (must be json, text, both, or junit)", format)
 		}
 	}
 
@@ -208,7 +462,20 @@ func ParseConfig(task agent.Task) (*DebugConfig, error) {
 		cfg.SubmitFindings = submitFindings
 	}
 
+	// Parse active scanning permission
+	if allowActive, ok := configMap["allow_active_scans"].(bool); ok {
+		cfg.AllowActiveScans = allowActive
+	}
+
+	// Parse progress key (empty string disables publishing)
+	if progressKey, ok := configMap["progress_key"].(string); ok {
+		cfg.ProgressKey = progressKey
+	}
+
 	// Parse debug mission context fields
+	if mode, ok := configMap["mode"].(string); ok {
+		cfg.Mode = Mode(mode)
+	}
 	if component, ok := configMap["component"].(string); ok {
 		cfg.Component = component
 	}
@@ -219,25 +486,26 @@ func ParseConfig(task agent.Task) (*DebugConfig, error) {
 		cfg.Prefix = prefix
 	}
 
+	// Parse framework config fields
+	if address, ok := configMap["daemon_address"].(string); ok {
+		cfg.DaemonAddress = address
+	}
+	if endpoints, ok := configMap["registry_endpoints"]; ok {
+		cfg.RegistryEndpoints = stringList(endpoints)
+	}
+	if fake, ok := configMap["registry_fake"].(bool); ok {
+		cfg.RegistryFake = fake
+	}
+
 	// Parse network reconnaissance config fields
 	if subnet, ok := configMap["subnet"].(string); ok {
 		cfg.Subnet = subnet
 	}
-	if domains, ok := configMap["domains"].([]interface{}); ok {
-		cfg.Domains = make([]string, 0, len(domains))
-		for _, domain := range domains {
-			if domainName, ok := domain.(string); ok {
-				cfg.Domains = append(cfg.Domains, domainName)
-			}
-		}
+	if domains, ok := configMap["domains"]; ok {
+		cfg.Domains = stringList(domains)
 	}
-	if skipPhases, ok := configMap["skip_phases"].([]interface{}); ok {
-		cfg.SkipPhases = make([]string, 0, len(skipPhases))
-		for _, phase := range skipPhases {
-			if phaseName, ok := phase.(string); ok {
-				cfg.SkipPhases = append(cfg.SkipPhases, phaseName)
-			}
-		}
+	if skipPhases, ok := configMap["skip_phases"]; ok {
+		cfg.SkipPhases = stringList(skipPhases)
 	}
 	if generateIntel, ok := configMap["generate_intelligence"].(bool); ok {
 		cfg.GenerateIntelligence = generateIntel
@@ -264,9 +532,48 @@ func (c *DebugConfig) Validate() error {
 		return fmt.Errorf("test_timeout must be positive, got %v", c.TestTimeout)
 	}
 
+	// Validate concurrency
+	if c.Concurrency < 1 {
+		return fmt.Errorf("concurrency must be at least 1, got %d", c.Concurrency)
+	}
+
+	// Validate retry policy
+	if c.RetryAttempts < 1 {
+		return fmt.Errorf("retry_attempts must be at least 1, got %d", c.RetryAttempts)
+	}
+	if c.RetryBackoff < 0 {
+		return fmt.Errorf("retry_backoff must not be negative, got %v", c.RetryBackoff)
+	}
+
+	// Validate baseline settings
+	switch c.BaselineStorage {
+	case BaselineDisabled, BaselineMission, BaselineLongTerm:
+		// valid
+	case BaselineFile:
+		if c.BaselinePath == "" {
+			return fmt.Errorf("baseline_path is required for file baselines")
+		}
+	default:
+		return fmt.Errorf("invalid baseline_storage: %s (must be file, mission, or long_term)", c.BaselineStorage)
+	}
+	if c.BaselineStorage != BaselineDisabled && c.BaselineName == "" {
+		return fmt.Errorf("baseline_name must not be empty")
+	}
+	if c.RegressionThreshold < 0 {
+		return fmt.Errorf("regression_threshold must not be negative, got %v", c.RegressionThreshold)
+	}
+
+	// Validate fail-fast scope
+	switch c.FailFast {
+	case "", "suite", "category", "module":
+		// valid
+	default:
+		return fmt.Errorf("invalid fail_fast: %s (must be suite, category, or module)", c.FailFast)
+	}
+
 	// Validate output format
 	switch c.OutputFormat {
-	case OutputJSON, OutputText, OutputBoth:
+	case OutputJSON, OutputText, OutputBoth, OutputJUnit:
 		// valid
 	default:
 		return fmt.Errorf("invalid output format: %s", c.OutputFormat)
@@ -286,13 +593,119 @@ func (c *DebugConfig) Validate() error {
 		}
 	}
 
-	return nil
+	// Validate the mode and the keys it needs
+	return c.validateMode()
 }
 
 // String returns a human-readable representation of the configuration
 func (c *DebugConfig) String() string {
-	return fmt.Sprintf("DebugConfig{verbose=%v, timeout=%v, output=%s}",
-		c.Verbose, c.Timeout, c.OutputFormat)
+	return fmt.Sprintf("DebugConfig{profile=%s, verbose=%v, timeout=%v, output=%s}",
+		c.Profile, c.Verbose, c.Timeout, c.OutputFormat)
+}
+
+// ConfigValue is the effective value of one configuration key and where it came from
+type ConfigValue struct {
+	Key    string `json:"key"`
+	Value  any    `json:"value"`
+	Source string `json:"source"`
+}
+
+// Effective returns the effective value and source of every configuration key
+// held in the config, in schema order
+func (c *DebugConfig) Effective() []ConfigValue {
+	values := make([]ConfigValue, 0, len(configSchema))
+	for _, field := range configSchema {
+		value, ok := c.value(field.Key)
+		if !ok {
+			continue
+		}
+		source, ok := c.Sources[field.Key]
+		if !ok {
+			source = sourceDefault
+		}
+		values = append(values, ConfigValue{Key: field.Key, Value: value, Source: source})
+	}
+	return values
+}
+
+// value returns the effective value of a configuration key, or false when the
+// key is not held in DebugConfig
+func (c *DebugConfig) value(key string) (any, bool) {
+	switch key {
+	case "profile":
+		return c.Profile, true
+	case "verbose":
+		return c.Verbose, true
+	case "tests":
+		return c.TargetTests, true
+	case "timeout":
+		return c.Timeout.String(), true
+	case "category_timeout":
+		return c.CategoryTimeout.String(), true
+	case "test_timeout":
+		return c.TestTimeout.String(), true
+	case "concurrency":
+		return c.Concurrency, true
+	case "retry_attempts":
+		return c.RetryAttempts, true
+	case "retry_backoff":
+		return c.RetryBackoff.String(), true
+	case "fail_fast":
+		return c.FailFast, true
+	case "shuffle":
+		return c.Shuffle, true
+	case "shuffle_seed":
+		return c.ShuffleSeed, c.Shuffle
+	case "progress_key":
+		return c.ProgressKey, true
+	case "baseline_storage":
+		return string(c.BaselineStorage), true
+	case "baseline_path":
+		return c.BaselinePath, true
+	case "baseline_name":
+		return c.BaselineName, true
+	case "save_baseline":
+		return c.SaveBaseline, true
+	case "regression_threshold":
+		return c.RegressionThreshold, true
+	case "fail_on_regression":
+		return c.FailOnRegression, true
+	case "dry_run":
+		return c.DryRun, true
+	case "output_format":
+		return string(c.OutputFormat), true
+	case "allow_active_scans":
+		return c.AllowActiveScans, true
+	case "submit_findings":
+		return c.SubmitFindings, true
+	case "skip_categories":
+		return c.SkipCategories, true
+	case "skip_tests":
+		return c.SkipTests, true
+	case "mode":
+		return string(c.Mode), true
+	case "component":
+		return c.Component, true
+	case "method":
+		return c.Method, true
+	case "prefix":
+		return c.Prefix, true
+	case "daemon_address":
+		return c.DaemonAddress, true
+	case "registry_endpoints":
+		return c.RegistryEndpoints, true
+	case "registry_fake":
+		return c.RegistryFake, true
+	case "subnet":
+		return c.Subnet, true
+	case "domains":
+		return c.Domains, true
+	case "skip_phases":
+		return c.SkipPhases, true
+	case "generate_intelligence":
+		return c.GenerateIntelligence, true
+	}
+	return nil, false
 }
 
 // ShouldRunPhase returns true if the given reconnaissance phase should be run
@@ -341,6 +754,17 @@ func (c *DebugConfig) ShouldRunCategory(category string) bool {
 	return true
 }
 
+// stringList converts a decoded string list; the config schema has already
+// checked that every item is a string
+func stringList(value any) []string {
+	items := stringListItems(value)
+	list := make([]string, 0, len(items))
+	for _, item := range items {
+		list = append(list, item.(string))
+	}
+	return list
+}
+
 // getConfigMapKeys returns the keys of a map[string]any for debugging
 func getConfigMapKeys(m map[string]any) []string {
 	keys := make([]string, 0, len(m))
@@ -0,0 +1,439 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zero-day-ai/agents/debug/internal/health"
+	"github.com/zero-day-ai/agents/debug/internal/llmtest"
+)
+
+// configType is a value type accepted for a configuration key
+type configType string
+
+const (
+	// typeBoolean accepts true or false
+	typeBoolean configType = "boolean"
+
+	// typeString accepts any string, restricted to the field's Enum when set
+	typeString configType = "string"
+
+	// typeDuration accepts a Go duration string such as "90s" or "10m"
+	typeDuration configType = "duration"
+
+	// typeInteger accepts a whole number
+	typeInteger configType = "integer"
+
+	// typeNumber accepts any number
+	typeNumber configType = "number"
+
+	// typeIntegerString accepts a string holding a whole number
+	typeIntegerString configType = "integer string"
+
+	// typeStringList accepts an array of strings, each restricted to the field's Enum when set
+	typeStringList configType = "string list"
+)
+
+// durationPattern matches the strings accepted by time.ParseDuration
+const durationPattern = `^[-+]?(0|([0-9]*\.?[0-9]+(ns|us|µs|ms|s|m|h))+)$`
+
+// configField declares one key accepted in the task context
+type configField struct {
+	Key string
+
+	// Types lists the accepted value types; the first is the documented one
+	Types []configType
+
+	// Enum lists the allowed string values (of the string or its list items)
+	Enum []string
+
+	Description string
+
+	// TaskOnly keys can only be set by the task, not by the config file,
+	// environment or command line
+	TaskOnly bool
+}
+
+// configSchema declares every key the debug agent accepts in its task context.
+// ParseConfig rejects keys that are not listed here and values of the wrong type.
+var configSchema = []configField{
+	{Key: "profile", Types: []configType{typeString}, Description: "Named set of defaults to start from: smoke, full, nightly or a profile from the config file"},
+	{Key: "verbose", Types: []configType{typeBoolean}, Description: "Enable detailed output"},
+	{Key: "tests", Types: []configType{typeStringList}, Description: "Restrict the run to these modules, tests, test IDs, requirement IDs or tags"},
+	{Key: "timeout", Types: []configType{typeDuration}, Description: "Overall execution timeout"},
+	{Key: "category_timeout", Types: []configType{typeDuration}, Description: "Deadline per test category, started when its first module starts"},
+	{Key: "test_timeout", Types: []configType{typeDuration}, Description: "Deadline per individual test"},
+	{Key: "concurrency", Types: []configType{typeInteger}, Description: "Maximum number of test modules run at the same time"},
+	{Key: "retry_attempts", Types: []configType{typeInteger}, Description: "Default attempts per test, including the first"},
+	{Key: "retry_backoff", Types: []configType{typeDuration}, Description: "Delay before the first retry, doubled for each further retry"},
+	{Key: "fail_fast", Types: []configType{typeString, typeBoolean}, Enum: []string{"", "suite", "category", "module"}, Description: "Abort the remaining tests after the first failure (true means suite)"},
+	{Key: "shuffle", Types: []configType{typeBoolean}, Description: "Run modules and tests in random order"},
+	{Key: "shuffle_seed", Types: []configType{typeInteger, typeIntegerString}, Description: "Seed reproducing a shuffled order; setting it enables shuffle"},
+	{Key: "progress_key", Types: []configType{typeString}, Description: "Working memory key for live progress; empty disables publishing"},
+	{Key: "baseline_storage", Types: []configType{typeString}, Enum: []string{"", "file", "mission", "long_term"}, Description: "Where run baselines are kept; empty disables baselines"},
+	{Key: "baseline_path", Types: []configType{typeString}, Description: "Directory for file baselines"},
+	{Key: "baseline_name", Types: []configType{typeString}, Description: "Baseline the run is compared against"},
+	{Key: "save_baseline", Types: []configType{typeString}, Description: "Name under which the run is saved as a baseline; empty disables saving"},
+	{Key: "regression_threshold", Types: []configType{typeNumber}, Description: "Relative slowdown reported as a duration regression; 0 disables duration checks"},
+	{Key: "fail_on_regression", Types: []configType{typeBoolean}, Description: "Fail the agent result when the run regressed against the baseline"},
+	{Key: "dry_run", Types: []configType{typeBoolean}, Description: "Report the execution plan instead of running the suite"},
+	{Key: "output_format", Types: []configType{typeString}, Enum: []string{"text", "json", "both", "junit"}, Description: "Report format"},
+	{Key: "allow_active_scans", Types: []configType{typeBoolean}, Description: "Allow tests that actively scan the network (ping sweeps, port scans)"},
+	{Key: "submit_findings", Types: []configType{typeBoolean}, Description: "Submit failed tests as findings"},
+	{Key: "skip_categories", Types: []configType{typeStringList}, Enum: []string{"sdk", "framework"}, Description: "Test categories to skip"},
+	{Key: "skip_tests", Types: []configType{typeStringList}, Description: "Modules, tests, test IDs, requirement IDs or tags to skip"},
+
+	// Debug mission context
+	{Key: "mode", Types: []configType{typeString}, Enum: modeNames(), Description: "Execution mode: a test suite or one targeted diagnostic"},
+	{Key: "component", Types: []configType{typeString}, Enum: health.Components, Description: "Component to test in health-check mode"},
+	{Key: "method", Types: []configType{typeString}, Enum: llmtest.Methods, Description: "LLM method to test in llm-test mode"},
+	{Key: "prefix", Types: []configType{typeString}, Description: "Prefix for test data"},
+	{Key: "goal", Types: []configType{typeString}, Description: "Task goal, set by the framework", TaskOnly: true},
+
+	// Framework
+	{Key: "daemon_address", Types: []configType{typeString}, Description: "gRPC address (host:port) of the Gibson daemon; GIBSON_DAEMON_ADDRESS when empty"},
+	{Key: "registry_endpoints", Types: []configType{typeStringList}, Description: "etcd endpoints of the component registry; GIBSON_REGISTRY_ENDPOINTS when empty"},
+	{Key: "registry_fake", Types: []configType{typeBoolean}, Description: "Run the registry tests against a partial in-memory etcd emulation; only checks the SDK client"},
+
+	// Network reconnaissance
+	{Key: "subnet", Types: []configType{typeString}, Description: "CIDR subnet to scan; auto-discovered when empty"},
+	{Key: "domains", Types: []configType{typeStringList}, Description: "Domains to enumerate; read from /etc/hosts when empty"},
+	{Key: "skip_phases", Types: []configType{typeStringList}, Enum: []string{"discover", "probe", "scan", "domain", "analyze"}, Description: "Reconnaissance phases to skip"},
+	{Key: "generate_intelligence", Types: []configType{typeBoolean}, Description: "Run LLM analysis of reconnaissance results"},
+}
+
+// ConfigProblem is one configuration value that does not match the config schema
+type ConfigProblem struct {
+	// Path locates the value, e.g. "context.tests[2]"
+	Path    string
+	Message string
+}
+
+// ConfigError reports every problem found while decoding the configuration layers
+type ConfigError struct {
+	Problems []ConfigProblem
+}
+
+// Error lists the problems, one per line when there are several
+func (e *ConfigError) Error() string {
+	if len(e.Problems) == 1 {
+		return fmt.Sprintf("invalid configuration: %s: %s", e.Problems[0].Path, e.Problems[0].Message)
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "invalid configuration (%d problems):", len(e.Problems))
+	for _, p := range e.Problems {
+		fmt.Fprintf(&b, "\n  %s: %s", p.Path, p.Message)
+	}
+	return b.String()
+}
+
+// lookupConfigField returns the schema entry for key
+func lookupConfigField(key string) (configField, bool) {
+	for _, field := range configSchema {
+		if field.Key == key {
+			return field, true
+		}
+	}
+	return configField{}, false
+}
+
+// checkConfigMap validates the keys of m against the config schema, reporting
+// problems under the given path prefix (e.g. "context."). Unknown keys are
+// problems only when strict is set.
+func checkConfigMap(prefix string, m map[string]any, strict bool) []ConfigProblem {
+	var problems []ConfigProblem
+	for key, value := range m {
+		path := prefix + key
+		field, ok := lookupConfigField(key)
+		if !ok {
+			if strict {
+				message := "unknown key"
+				if suggestion := suggestConfigKey(key); suggestion != "" {
+					message += fmt.Sprintf(" (did you mean %q?)", suggestion)
+				}
+				problems = append(problems, ConfigProblem{Path: path, Message: message})
+			}
+			continue
+		}
+		problems = append(problems, field.check(path, value)...)
+	}
+	sort.Slice(problems, func(i, j int) bool { return problems[i].Path < problems[j].Path })
+	return problems
+}
+
+// check validates value against the field, choosing the accepted type that has
+// the value's JSON kind
+func (f configField) check(path string, value any) []ConfigProblem {
+	kind := jsonKind(value)
+	for _, t := range f.Types {
+		if t.kind() == kind {
+			return t.check(path, value, f.Enum)
+		}
+	}
+
+	expected := make([]string, len(f.Types))
+	for i, t := range f.Types {
+		expected[i] = string(t)
+	}
+	return []ConfigProblem{{
+		Path:    path,
+		Message: fmt.Sprintf("expected %s, got %s", strings.Join(expected, " or "), describeJSON(value)),
+	}}
+}
+
+// parseString decodes a value given as text (an environment variable or command
+// line flag), choosing the first accepted type the text parses as
+func (f configField) parseString(path, s string) (any, []ConfigProblem) {
+	var first []ConfigProblem
+	for _, t := range f.Types {
+		value, err := t.parseString(s)
+		if err != nil {
+			if first == nil {
+				first = []ConfigProblem{{Path: path, Message: err.Error()}}
+			}
+			continue
+		}
+		problems := t.check(path, value, f.Enum)
+		if problems == nil {
+			return value, nil
+		}
+		if first == nil {
+			first = problems
+		}
+	}
+	return nil, first
+}
+
+// parseString converts text to a value of the type's JSON kind; string lists
+// are comma separated
+func (t configType) parseString(s string) (any, error) {
+	switch t {
+	case typeBoolean:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, fmt.Errorf("expected boolean, got %q", s)
+		}
+		return b, nil
+
+	case typeInteger:
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return nil, fmt.Errorf("expected integer, got %q", s)
+		}
+		return n, nil
+
+	case typeNumber:
+		n, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, fmt.Errorf("expected number, got %q", s)
+		}
+		return n, nil
+
+	case typeStringList:
+		items := []any{}
+		for _, item := range strings.Split(s, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		return items, nil
+	}
+	return s, nil
+}
+
+// kind returns the JSON kind a value of the type is encoded as
+func (t configType) kind() string {
+	switch t {
+	case typeBoolean:
+		return "boolean"
+	case typeInteger, typeNumber:
+		return "number"
+	case typeStringList:
+		return "array"
+	}
+	return "string"
+}
+
+// check validates a value that already has the type's JSON kind
+func (t configType) check(path string, value any, enum []string) []ConfigProblem {
+	problem := func(format string, args ...any) []ConfigProblem {
+		return []ConfigProblem{{Path: path, Message: fmt.Sprintf(format, args...)}}
+	}
+
+	switch t {
+	case typeString:
+		if s := value.(string); len(enum) > 0 && !slices.Contains(enum, s) {
+			return problem("must be one of %s, got %q", formatEnum(enum), s)
+		}
+
+	case typeDuration:
+		if _, err := time.ParseDuration(value.(string)); err != nil {
+			return problem("invalid duration %q (e.g. \"90s\", \"10m\")", value)
+		}
+
+	case typeIntegerString:
+		if _, err := strconv.ParseInt(value.(string), 10, 64); err != nil {
+			return problem("expected integer, got string %q", value)
+		}
+
+	case typeInteger:
+		if f, ok := value.(float64); ok && f != float64(int64(f)) {
+			return problem("expected integer, got number %v", f)
+		}
+
+	case typeStringList:
+		var problems []ConfigProblem
+		for i, item := range stringListItems(value) {
+			itemPath := fmt.Sprintf("%s[%d]", path, i)
+			if _, ok := item.(string); !ok {
+				problems = append(problems, ConfigProblem{Path: itemPath, Message: "expected string, got " + describeJSON(item)})
+				continue
+			}
+			problems = append(problems, typeString.check(itemPath, item, enum)...)
+		}
+		return problems
+	}
+	return nil
+}
+
+// jsonKind returns the JSON kind of a decoded value
+func jsonKind(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case float64, int:
+		return "number"
+	case []any, []string:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// describeJSON describes a value for error messages, e.g. `string "true"`
+func describeJSON(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return fmt.Sprintf("string %q", v)
+	case []any, []string, map[string]any:
+		return jsonKind(value)
+	}
+	return fmt.Sprintf("%s %v", jsonKind(value), value)
+}
+
+// stringListItems returns the items of a decoded array
+func stringListItems(value any) []any {
+	switch v := value.(type) {
+	case []any:
+		return v
+	case []string:
+		items := make([]any, len(v))
+		for i, s := range v {
+			items[i] = s
+		}
+		return items
+	}
+	return nil
+}
+
+// formatEnum quotes the allowed values for error messages
+func formatEnum(enum []string) string {
+	quoted := make([]string, len(enum))
+	for i, value := range enum {
+		quoted[i] = strconv.Quote(value)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// suggestConfigKey returns the schema key closest to an unknown key, or "" when
+// none is close enough to be a likely typo
+func suggestConfigKey(key string) string {
+	best, bestDistance := "", 3
+	for _, field := range configSchema {
+		if d := editDistance(key, field.Key); d < bestDistance {
+			best, bestDistance = field.Key, d
+		}
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+// ConfigJSONSchema returns the task context schema as a JSON Schema document, for
+// validating the context: blocks of mission files before they are run
+func ConfigJSONSchema() ([]byte, error) {
+	properties := make(map[string]any, len(configSchema))
+	for _, field := range configSchema {
+		variants := make([]map[string]any, len(field.Types))
+		for i, t := range field.Types {
+			variants[i] = t.jsonSchema(field.Enum)
+		}
+
+		property := variants[0]
+		if len(variants) > 1 {
+			property = map[string]any{"anyOf": variants}
+		}
+		property["description"] = field.Description
+		properties[field.Key] = property
+	}
+
+	doc := map[string]any{
+		"$schema":              "https://json-schema.org/draft/2020-12/schema",
+		"title":                agentName + " task context",
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// jsonSchema returns the JSON Schema of the type
+func (t configType) jsonSchema(enum []string) map[string]any {
+	switch t {
+	case typeBoolean, typeInteger, typeNumber:
+		return map[string]any{"type": string(t)}
+	case typeDuration:
+		return map[string]any{"type": "string", "pattern": durationPattern}
+	case typeIntegerString:
+		return map[string]any{"type": "string", "pattern": `^[-+]?[0-9]+$`}
+	case typeStringList:
+		return map[string]any{"type": "array", "items": typeString.jsonSchema(enum)}
+	}
+
+	s := map[string]any{"type": "string"}
+	if len(enum) > 0 {
+		s["enum"] = enum
+	}
+	return s
+}
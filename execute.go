@@ -8,9 +8,20 @@ import (
 
 	"github.com/zero-day-ai/sdk/agent"
 
+	"github.com/zero-day-ai/agents/debug/internal/daemon"
+	"github.com/zero-day-ai/agents/debug/internal/delegation"
+	"github.com/zero-day-ai/agents/debug/internal/findings"
 	"github.com/zero-day-ai/agents/debug/internal/framework"
+	"github.com/zero-day-ai/agents/debug/internal/graphrag"
+	"github.com/zero-day-ai/agents/debug/internal/health"
+	"github.com/zero-day-ai/agents/debug/internal/llmtest"
+	"github.com/zero-day-ai/agents/debug/internal/memory"
+	"github.com/zero-day-ai/agents/debug/internal/registry"
 	"github.com/zero-day-ai/agents/debug/internal/runner"
 	"github.com/zero-day-ai/agents/debug/internal/sdk"
+	"github.com/zero-day-ai/agents/debug/internal/summary"
+	"github.com/zero-day-ai/agents/debug/internal/tools"
+	"github.com/zero-day-ai/agents/debug/internal/workflow"
 )
 
 // executeDebugAgent is the main execution function for the debug agent.
@@ -46,16 +57,64 @@ func executeDebugAgent(ctx context.Context, h agent.Harness, task agent.Task) (a
 	}
 
 	logger.Info("Configuration parsed",
+		"profile", cfg.Profile,
+		"mode", cfg.Mode,
 		"verbose", cfg.Verbose,
 		"timeout", cfg.Timeout,
+		"concurrency", cfg.Concurrency,
+		"category_timeout", cfg.CategoryTimeout,
+		"retry_attempts", cfg.RetryAttempts,
+		"fail_fast", cfg.FailFast,
+		"shuffle", cfg.Shuffle,
+		"shuffle_seed", cfg.ShuffleSeed,
+		"target_tests", cfg.TargetTests,
+		"skip_tests", cfg.SkipTests,
+		"skip_categories", cfg.SkipCategories,
 		"output_format", cfg.OutputFormat,
+		"dry_run", cfg.DryRun,
 	)
 
+	// Delegated tasks and summaries bypass the test suite
+	spec := modes[cfg.Mode]
+	if !spec.suite() {
+		return executeMode(ctx, h, task, cfg, spec)
+	}
+
 	// Create the test runner
 	testRunner := runner.NewRunner(h, cfg.Timeout, cfg.TestTimeout)
+	testRunner.SetConcurrency(cfg.Concurrency)
+	testRunner.SetCategoryTimeout(cfg.CategoryTimeout)
+	testRunner.SetRetryPolicy(runner.RetryPolicy{
+		MaxAttempts:   cfg.RetryAttempts,
+		Backoff:       cfg.RetryBackoff,
+		BackoffFactor: 2,
+	})
+	testRunner.SetFailFast(runner.FailFastScope(cfg.FailFast))
+	if cfg.Shuffle {
+		testRunner.SetShuffle(cfg.ShuffleSeed)
+	}
+	selection := runner.Selection{
+		Targets:        cfg.TargetTests,
+		SkipTests:      cfg.SkipTests,
+		SkipCategories: cfg.SkipCategories,
+	}
+	if !cfg.AllowActiveScans {
+		selection.ForbidTags = map[string]string{
+			runner.TagActive: "active network scanning is not allowed (allow_active_scans from " + cfg.Sources["allow_active_scans"] + ")",
+		}
+	}
+	testRunner.SetSelectionPolicy(selection)
+
+	// Publish live progress to the log and, for other agents, to working memory
+	testRunner.AddObserver(runner.NewLogObserver(logger))
+	if cfg.ProgressKey != "" {
+		if mem := h.Memory(); mem != nil && mem.Working() != nil {
+			testRunner.AddObserver(runner.NewMemoryObserver(mem.Working(), cfg.ProgressKey, logger))
+		}
+	}
 
-	// Register test modules
-	if err := registerTestModules(testRunner, cfg); err != nil {
+	// Register the test modules the mode runs
+	if err := registerTestModules(testRunner, cfg, spec); err != nil {
 		logger.Error("Failed to register test modules",
 			"error", err,
 		)
@@ -71,6 +130,11 @@ func executeDebugAgent(ctx context.Context, h agent.Harness, task agent.Task) (a
 		"framework_modules", len(testRunner.GetModulesByCategory(runner.CategoryFramework)),
 	)
 
+	// In dry-run mode report the plan instead of executing anything
+	if cfg.DryRun {
+		return executeDryRun(ctx, testRunner, cfg, startTime)
+	}
+
 	// Execute the full test suite
 	suiteResult, err := testRunner.Run(ctx)
 
@@ -93,12 +157,28 @@ func executeDebugAgent(ctx context.Context, h agent.Harness, task agent.Task) (a
 		"failed", suiteResult.TotalFailed(),
 		"skipped", suiteResult.TotalSkipped(),
 		"errors", suiteResult.TotalErrors(),
+		"flaky", suiteResult.TotalFlaky(),
+		"not_implemented", suiteResult.TotalNotImplemented(),
 		"overall_status", suiteResult.OverallStatus,
 		"pass_rate", fmt.Sprintf("%.2f%%", suiteResult.OverallPassRate()*100),
 	)
 
+	// Compare against the previous baseline and save this run
+	comparison := compareBaseline(ctx, h, cfg, suiteResult)
+
+	// Publish counts for a later summary task
+	publishPhases(ctx, h, spec, suiteResult)
+
+	return suiteAgentResult(h, cfg, suiteResult, comparison, startTime), nil
+}
+
+// suiteAgentResult reports a finished suite as the agent result, in the
+// configured output format and with summary counts as metadata
+func suiteAgentResult(h agent.Harness, cfg *DebugConfig, suiteResult *runner.SuiteResult, comparison *runner.Comparison, startTime time.Time) agent.Result {
+	logger := h.Logger()
+
 	// Generate output based on format
-	output := formatOutput(suiteResult, cfg)
+	output := formatOutput(suiteResult, comparison, cfg)
 
 	// Determine result status
 	resultStatus := agent.StatusSuccess
@@ -107,33 +187,62 @@ func executeDebugAgent(ctx context.Context, h agent.Harness, task agent.Task) (a
 	} else if suiteResult.OverallStatus == runner.TestStatusError {
 		resultStatus = agent.StatusFailed
 	}
+	if cfg.FailOnRegression && comparison.HasRegressions() {
+		logger.Warn("Run regressed against baseline",
+			"baseline", comparison.BaselineName,
+			"summary", comparison.Summary(),
+		)
+		resultStatus = agent.StatusFailed
+	}
 
 	// Build result metadata
 	metadata := map[string]any{
-		"duration":       suiteResult.Duration().String(),
-		"total_tests":    suiteResult.TotalTests(),
-		"passed":         suiteResult.TotalPassed(),
-		"failed":         suiteResult.TotalFailed(),
-		"skipped":        suiteResult.TotalSkipped(),
-		"errors":         suiteResult.TotalErrors(),
-		"pass_rate":      suiteResult.OverallPassRate(),
-		"overall_status": suiteResult.OverallStatus,
+		"duration":        suiteResult.Duration().String(),
+		"total_tests":     suiteResult.TotalTests(),
+		"passed":          suiteResult.TotalPassed(),
+		"failed":          suiteResult.TotalFailed(),
+		"skipped":         suiteResult.TotalSkipped(),
+		"errors":          suiteResult.TotalErrors(),
+		"flaky":           suiteResult.TotalFlaky(),
+		"not_implemented": suiteResult.TotalNotImplemented(),
+		"pass_rate":       suiteResult.OverallPassRate(),
+		"overall_status":  suiteResult.OverallStatus,
 		"sdk_summary": map[string]any{
-			"total":   suiteResult.SDKSummary.Total,
-			"passed":  suiteResult.SDKSummary.Passed,
-			"failed":  suiteResult.SDKSummary.Failed,
-			"skipped": suiteResult.SDKSummary.Skipped,
-			"errors":  suiteResult.SDKSummary.Errors,
+			"total":           suiteResult.SDKSummary.Total,
+			"passed":          suiteResult.SDKSummary.Passed,
+			"failed":          suiteResult.SDKSummary.Failed,
+			"skipped":         suiteResult.SDKSummary.Skipped,
+			"errors":          suiteResult.SDKSummary.Errors,
+			"flaky":           suiteResult.SDKSummary.Flaky,
+			"not_implemented": suiteResult.SDKSummary.NotImplemented,
 		},
 		"framework_summary": map[string]any{
-			"total":   suiteResult.FrameworkSummary.Total,
-			"passed":  suiteResult.FrameworkSummary.Passed,
-			"failed":  suiteResult.FrameworkSummary.Failed,
-			"skipped": suiteResult.FrameworkSummary.Skipped,
-			"errors":  suiteResult.FrameworkSummary.Errors,
+			"total":           suiteResult.FrameworkSummary.Total,
+			"passed":          suiteResult.FrameworkSummary.Passed,
+			"failed":          suiteResult.FrameworkSummary.Failed,
+			"skipped":         suiteResult.FrameworkSummary.Skipped,
+			"errors":          suiteResult.FrameworkSummary.Errors,
+			"flaky":           suiteResult.FrameworkSummary.Flaky,
+			"not_implemented": suiteResult.FrameworkSummary.NotImplemented,
 		},
 	}
 
+	if suiteResult.Shuffled {
+		metadata["shuffle_seed"] = suiteResult.ShuffleSeed
+	}
+
+	if comparison != nil {
+		metadata["baseline_comparison"] = map[string]any{
+			"baseline":             comparison.BaselineName,
+			"newly_failing":        len(comparison.NewlyFailing),
+			"newly_passing":        len(comparison.NewlyPassing),
+			"disappeared":          len(comparison.Disappeared),
+			"added":                len(comparison.Added),
+			"duration_regressions": len(comparison.DurationRegressions),
+			"regressed":            comparison.HasRegressions(),
+		}
+	}
+
 	logger.Info("Debug agent execution finished",
 		"status", resultStatus,
 		"total_duration", time.Since(startTime),
@@ -143,27 +252,63 @@ func executeDebugAgent(ctx context.Context, h agent.Harness, task agent.Task) (a
 		Status:   resultStatus,
 		Output:   output,
 		Metadata: metadata,
-	}, nil
+	}
 }
 
-// registerTestModules registers test modules with the runner
-func registerTestModules(testRunner *runner.Runner, cfg *DebugConfig) error {
-	// Register SDK test modules with subnet from config
-	testRunner.RegisterModule(sdk.NewComprehensiveSDKModule(cfg.Subnet))
+// registerTestModules registers the test modules the mode selects with the runner
+func registerTestModules(testRunner *runner.Runner, cfg *DebugConfig, spec modeSpec) error {
+	modules := []runner.TestModule{
+		// SDK diagnostics; component and method are only set by the modes
+		// targeting a single health check or LLM method
+		health.NewModule(cfg.Component),
+		memory.NewModule(),
+		tools.NewModule(),
+		graphrag.NewModule(cfg.Prefix),
+		llmtest.NewModule(cfg.Method),
+		delegation.NewModule(),
+		findings.NewModule(cfg.Prefix),
+
+		// SDK test modules with subnet from config
+		sdk.NewComprehensiveSDKModule(cfg.Subnet),
+
+		// Framework test modules
+		daemon.NewModule(cfg.DaemonAddress),
+		registry.NewModule(cfg.RegistryEndpoints, cfg.RegistryFake),
+		workflow.NewModule(bundledMissions(), missionOptions(nil)),
+		framework.NewComprehensiveFrameworkModule(),
+
+		// Summary aggregation of published phase results
+		summary.NewModule(),
+	}
 
-	// Register Framework test modules
-	testRunner.RegisterModule(framework.NewComprehensiveFrameworkModule())
+	for _, module := range modules {
+		if !spec.selects(module) {
+			continue
+		}
+		if err := testRunner.RegisterModule(module); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
 
 // formatOutput generates the output string based on configured format
-func formatOutput(suiteResult *runner.SuiteResult, cfg *DebugConfig) string {
+func formatOutput(suiteResult *runner.SuiteResult, comparison *runner.Comparison, cfg *DebugConfig) string {
 	var output string
 
+	// JUnit XML is a standalone document for CI ingestion
+	if cfg.OutputFormat == OutputJUnit {
+		return formatJUnitOutput(suiteResult)
+	}
+
 	// Build text output
 	if cfg.OutputFormat == OutputText || cfg.OutputFormat == OutputBoth {
 		output += formatTextOutput(suiteResult)
+		if comparison != nil {
+			output += formatComparisonText(comparison)
+		}
+		output += formatConfigText(cfg)
 	}
 
 	// Build JSON output
@@ -171,7 +316,7 @@ func formatOutput(suiteResult *runner.SuiteResult, cfg *DebugConfig) string {
 		if output != "" {
 			output += "\n\n--- JSON Output ---\n\n"
 		}
-		output += formatJSONOutput(suiteResult)
+		output += formatJSONOutput(suiteResult, comparison, cfg)
 	}
 
 	return output
@@ -190,6 +335,8 @@ Passed: %d (%.1f%%)
 Failed: %d
 Skipped: %d
 Errors: %d
+Flaky: %d
+Not Implemented: %d
 
 === SDK Tests ===
 Total: %d
@@ -197,6 +344,8 @@ Passed: %d
 Failed: %d
 Skipped: %d
 Errors: %d
+Flaky: %d
+Not Implemented: %d
 
 === Framework Tests ===
 Total: %d
@@ -204,6 +353,8 @@ Passed: %d
 Failed: %d
 Skipped: %d
 Errors: %d
+Flaky: %d
+Not Implemented: %d
 `,
 		suiteResult.Duration(),
 		suiteResult.OverallStatus,
@@ -213,18 +364,30 @@ Errors: %d
 		suiteResult.TotalFailed(),
 		suiteResult.TotalSkipped(),
 		suiteResult.TotalErrors(),
+		suiteResult.TotalFlaky(),
+		suiteResult.TotalNotImplemented(),
 		suiteResult.SDKSummary.Total,
 		suiteResult.SDKSummary.Passed,
 		suiteResult.SDKSummary.Failed,
 		suiteResult.SDKSummary.Skipped,
 		suiteResult.SDKSummary.Errors,
+		suiteResult.SDKSummary.Flaky,
+		suiteResult.SDKSummary.NotImplemented,
 		suiteResult.FrameworkSummary.Total,
 		suiteResult.FrameworkSummary.Passed,
 		suiteResult.FrameworkSummary.Failed,
 		suiteResult.FrameworkSummary.Skipped,
 		suiteResult.FrameworkSummary.Errors,
+		suiteResult.FrameworkSummary.Flaky,
+		suiteResult.FrameworkSummary.NotImplemented,
 	)
 
+	// Print the seed so a shuffled run can be reproduced
+	if suiteResult.Shuffled {
+		output += fmt.Sprintf("\n=== Shuffled Order ===\nSeed: %d (set shuffle_seed to reproduce this order)\n",
+			suiteResult.ShuffleSeed)
+	}
+
 	// Add failed test details
 	if suiteResult.TotalFailed() > 0 || suiteResult.TotalErrors() > 0 {
 		output += "\n=== Failed/Error Tests ===\n"
@@ -235,19 +398,67 @@ Errors: %d
 					result.TestName,
 					result.RequirementID,
 				)
+				if result.TestID != "" {
+					output += fmt.Sprintf("  ID: %s\n", result.TestID)
+				}
 				output += fmt.Sprintf("  Message: %s\n", result.Message)
-				if result.Error != nil {
-					output += fmt.Sprintf("  Error: %v\n", result.Error)
+				if testErr := runner.AsTestError(result.Error); testErr != nil {
+					output += fmt.Sprintf("  Error (%s): %s\n", testErr.Kind, testErr.Message)
+					if testErr.HarnessCall != "" {
+						output += fmt.Sprintf("  Harness call: %s\n", testErr.HarnessCall)
+					}
+					for _, cause := range testErr.Chain {
+						output += fmt.Sprintf("    caused by: %s\n", cause)
+					}
 				}
 			}
 		}
 	}
 
+	// List unverified requirements so the report does not read as full coverage
+	if suiteResult.TotalNotImplemented() > 0 {
+		output += "\n=== Not Implemented (requirement unverified) ===\n"
+		for _, result := range suiteResult.Results {
+			if result.Status != runner.TestStatusNotImplemented {
+				continue
+			}
+			output += fmt.Sprintf("[Req %s] %s: %s\n", result.RequirementID, result.TestName, result.Message)
+		}
+	}
+
+	// Add flaky test details
+	if suiteResult.TotalFlaky() > 0 {
+		output += "\n=== Flaky Tests ===\n"
+		for _, result := range suiteResult.Results {
+			if result.Status != runner.TestStatusFlaky {
+				continue
+			}
+			output += fmt.Sprintf("\n[%s] %s (Req %s)\n",
+				result.Status,
+				result.TestName,
+				result.RequirementID,
+			)
+			for _, attempt := range result.Attempts {
+				output += fmt.Sprintf("  Attempt %d: %s - %s\n", attempt.Number, attempt.Status, attempt.Message)
+			}
+		}
+	}
+
 	return output
 }
 
+// formatJUnitOutput creates JUnit XML output
+func formatJUnitOutput(suiteResult *runner.SuiteResult) string {
+	xmlBytes, err := suiteResult.JUnitXML()
+	if err != nil {
+		return fmt.Sprintf("Error formatting JUnit XML: %v", err)
+	}
+
+	return string(xmlBytes)
+}
+
 // formatJSONOutput creates JSON output
-func formatJSONOutput(suiteResult *runner.SuiteResult) string {
+func formatJSONOutput(suiteResult *runner.SuiteResult, comparison *runner.Comparison, cfg *DebugConfig) string {
 	// Create a simplified structure for JSON output
 	jsonData := map[string]any{
 		"start_time":     suiteResult.StartTime,
@@ -255,15 +466,24 @@ func formatJSONOutput(suiteResult *runner.SuiteResult) string {
 		"duration":       suiteResult.Duration().String(),
 		"overall_status": suiteResult.OverallStatus,
 		"summary": map[string]any{
-			"total":   suiteResult.TotalTests(),
-			"passed":  suiteResult.TotalPassed(),
-			"failed":  suiteResult.TotalFailed(),
-			"skipped": suiteResult.TotalSkipped(),
-			"errors":  suiteResult.TotalErrors(),
+			"total":           suiteResult.TotalTests(),
+			"passed":          suiteResult.TotalPassed(),
+			"failed":          suiteResult.TotalFailed(),
+			"skipped":         suiteResult.TotalSkipped(),
+			"errors":          suiteResult.TotalErrors(),
+			"flaky":           suiteResult.TotalFlaky(),
+			"not_implemented": suiteResult.TotalNotImplemented(),
 		},
 		"sdk_summary":       suiteResult.SDKSummary,
 		"framework_summary": suiteResult.FrameworkSummary,
 		"results":           suiteResult.Results,
+		"config":            cfg.Effective(),
+	}
+	if suiteResult.Shuffled {
+		jsonData["shuffle_seed"] = suiteResult.ShuffleSeed
+	}
+	if comparison != nil {
+		jsonData["baseline_comparison"] = comparison
 	}
 
 	jsonBytes, err := json.MarshalIndent(jsonData, "", "  ")
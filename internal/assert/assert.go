@@ -0,0 +1,125 @@
+// Package assert provides assertions shared by the SDK and Framework test modules.
+//
+// Every assertion returns nil when the expectation holds and otherwise an error of
+// kind runner.ErrorKindAssertion describing the mismatch, so assertions compose
+// with ordinary error handling and end up structured in the report:
+//
+//	if err := assert.Equal(want, got); err != nil {
+//		return runner.NewFailResult(name, reqID, category, 0, "Scan results differ", err)
+//	}
+//
+// Result converts the outcome of an assertion into a TestResult directly.
+package assert
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/zero-day-ai/agents/debug/internal/runner"
+)
+
+// failf returns an assertion error
+func failf(format string, args ...any) error {
+	return runner.AssertionError(format, args...)
+}
+
+// Result converts the outcome of an assertion into a test result: a pass with
+// passMessage when err is nil, otherwise a failure carrying the assertion error
+func Result(testName, requirementID string, category runner.Category, err error, passMessage string) runner.TestResult {
+	if err != nil {
+		return runner.NewFailResult(testName, requirementID, category, 0, err.Error(), err)
+	}
+	return runner.NewPassResult(testName, requirementID, category, 0, passMessage)
+}
+
+// Equal checks that got deeply equals want, reporting every differing field
+func Equal(want, got any) error {
+	if reflect.DeepEqual(want, got) {
+		return nil
+	}
+	return failf("values differ:\n%s", Diff(want, got))
+}
+
+// NotEqual checks that got does not deeply equal want
+func NotEqual(notWant, got any) error {
+	if reflect.DeepEqual(notWant, got) {
+		return failf("expected value other than %s", formatValue(reflect.ValueOf(got)))
+	}
+	return nil
+}
+
+// Nil checks that value is nil, including typed nil pointers, maps and slices
+func Nil(value any) error {
+	if isNil(value) {
+		return nil
+	}
+	return failf("expected nil, got %s", formatValue(reflect.ValueOf(value)))
+}
+
+// NotNil checks that value is not nil, including typed nil pointers, maps and slices
+func NotNil(value any) error {
+	if isNil(value) {
+		return failf("expected non-nil value, got nil")
+	}
+	return nil
+}
+
+// True checks a condition, using the formatted description as the failure message
+func True(condition bool, format string, args ...any) error {
+	if condition {
+		return nil
+	}
+	return failf(format, args...)
+}
+
+// False checks that a condition does not hold
+func False(condition bool, format string, args ...any) error {
+	return True(!condition, format, args...)
+}
+
+// NoError checks that err is nil
+func NoError(err error) error {
+	if err != nil {
+		return failf("unexpected error: %w", err)
+	}
+	return nil
+}
+
+// ErrorIs checks that err matches target as reported by errors.Is
+func ErrorIs(err, target error) error {
+	if err == nil {
+		return failf("expected error matching %q, got nil", target)
+	}
+	if !errors.Is(err, target) {
+		return failf("expected error matching %q, got %q", target, err)
+	}
+	return nil
+}
+
+// isNil reports whether value is nil or a nil pointer, map, slice, channel or function
+func isNil(value any) bool {
+	if value == nil {
+		return true
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func, reflect.Interface:
+		return v.IsNil()
+	}
+	return false
+}
+
+// formatValue renders a value for failure messages, quoting strings
+func formatValue(v reflect.Value) string {
+	if !v.IsValid() {
+		return "nil"
+	}
+	if v.Kind() == reflect.String {
+		return fmt.Sprintf("%q", v.String())
+	}
+	if !v.CanInterface() {
+		return fmt.Sprintf("<unexported %s>", v.Type())
+	}
+	return fmt.Sprintf("%v", v.Interface())
+}
@@ -0,0 +1,149 @@
+package assert
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zero-day-ai/agents/debug/internal/runner"
+)
+
+type port struct {
+	Number  int
+	Service string
+}
+
+type host struct {
+	IP    string
+	Ports []port
+	Tags  map[string]string
+}
+
+func TestEqual_Diff(t *testing.T) {
+	want := []host{
+		{IP: "10.0.0.1", Ports: []port{{22, "ssh"}}, Tags: map[string]string{"os": "linux"}},
+		{IP: "10.0.0.2", Ports: []port{{80, "http"}, {443, "https"}}},
+	}
+	got := []host{
+		{IP: "10.0.0.1", Ports: []port{{22, "ssh"}}, Tags: map[string]string{"os": "windows", "role": "dc"}},
+		{IP: "10.0.0.3", Ports: []port{{80, "http"}}},
+	}
+
+	err := Equal(want, got)
+	if err == nil {
+		t.Fatal("Equal() = nil, want error")
+	}
+	if testErr := runner.AsTestError(err); testErr.Kind != runner.ErrorKindAssertion {
+		t.Errorf("Kind = %s, want assertion", testErr.Kind)
+	}
+	for _, line := range []string{
+		`[0].Tags["os"]: want "linux", got "windows"`,
+		`[0].Tags["role"]: unexpected "dc"`,
+		`[1].IP: want "10.0.0.2", got "10.0.0.3"`,
+		`[1].Ports[1]: missing, want {443 https}`,
+	} {
+		if !strings.Contains(err.Error(), line) {
+			t.Errorf("diff missing %q:\n%s", line, err)
+		}
+	}
+
+	if err := Equal(want, want); err != nil {
+		t.Errorf("Equal() of identical values = %v", err)
+	}
+}
+
+func TestEqual_DiffIsBounded(t *testing.T) {
+	want := make([]int, 50)
+	got := make([]int, 50)
+	for i := range got {
+		got[i] = i + 1
+	}
+
+	diff := Diff(want, got)
+	if lines := strings.Count(diff, "\n") + 1; lines != maxDiffLines+1 {
+		t.Errorf("Diff() has %d lines, want %d", lines, maxDiffLines+1)
+	}
+	if !strings.Contains(diff, "and 30 more differences") {
+		t.Errorf("Diff() does not summarise the remainder:\n%s", diff)
+	}
+}
+
+func TestAssertions(t *testing.T) {
+	var nilHost *host
+	notFound := errors.New("not found")
+
+	tests := []struct {
+		name    string
+		err     error
+		wantErr string
+	}{
+		{"nil typed pointer", Nil(nilHost), ""},
+		{"not nil", NotNil(nilHost), "expected non-nil"},
+		{"not equal", NotEqual(1, 1), "expected value other than 1"},
+		{"true", True(false, "expected %d hosts", 3), "expected 3 hosts"},
+		{"no error", NoError(notFound), "unexpected error: not found"},
+		{"error is", ErrorIs(errors.Join(notFound), notFound), ""},
+		{"error is nil", ErrorIs(nil, notFound), "got nil"},
+		{"greater", Greater(3, 5.0), "greater than 5, got 3"},
+		{"less", Less(uint8(2), 3), ""},
+		{"not a number", Greater("3", 1), `"3" is not a number`},
+		{"in delta", InDelta(0.3, 0.1+0.2, 1e-9), ""},
+		{"outside delta", InDelta(100, 103, 2), "expected 100 ± 2, got 103"},
+		{"in epsilon", InEpsilon(200, 190, 0.05), ""},
+		{"outside epsilon", InEpsilon(200, 180, 0.05), "off by 10.00%"},
+		{"string contains", Contains("nmap 7.94", "7.94"), ""},
+		{"slice contains", Contains([]string{"ssh", "http"}, "ftp"), `does not contain "ftp"`},
+		{"map key", Contains(map[string]int{"ssh": 22}, "ssh"), ""},
+		{"not contains", NotContains([]int{22, 80}, 80), "unexpectedly contains 80"},
+		{"contains all", ContainsAll([]int{22, 80, 443}, 22, 8080, 9090), "missing 8080, 9090"},
+		{"len", Len(map[string]int{"a": 1}, 2), "expected length 2, got 1"},
+		{"empty", Empty([]int{}), ""},
+		{"not empty", NotEmpty(""), "expected non-empty string"},
+		{"no length", Len(42, 0), "int has no length"},
+		{"elements match", ElementsMatch([]int{1, 2, 2}, []int{2, 1, 2}), ""},
+		{"elements differ", ElementsMatch([]int{1, 2}, []int{2, 3, 4}), "missing 1; unexpected 3, 4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantErr == "" {
+				if tt.err != nil {
+					t.Errorf("unexpected error: %v", tt.err)
+				}
+				return
+			}
+			if tt.err == nil || !strings.Contains(tt.err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want containing %q", tt.err, tt.wantErr)
+			}
+			if testErr := runner.AsTestError(tt.err); testErr != nil && testErr.Kind != runner.ErrorKindAssertion {
+				t.Errorf("Kind = %s, want assertion", testErr.Kind)
+			}
+		})
+	}
+}
+
+func TestEventually(t *testing.T) {
+	calls := 0
+	err := Eventually(context.Background(), time.Second, time.Millisecond, func(ctx context.Context) error {
+		calls++
+		if calls < 3 {
+			return errors.New("not indexed yet")
+		}
+		return nil
+	})
+	if err != nil || calls != 3 {
+		t.Errorf("Eventually() = %v after %d calls, want success after 3", err, calls)
+	}
+
+	err = Eventually(context.Background(), 20*time.Millisecond, 5*time.Millisecond, func(ctx context.Context) error {
+		return errors.New("not indexed yet")
+	})
+	if err == nil || !strings.Contains(err.Error(), "not indexed yet") {
+		t.Fatalf("Eventually() = %v, want last condition error", err)
+	}
+	if testErr := runner.AsTestError(err); testErr.Kind != runner.ErrorKindAssertion {
+		t.Errorf("Kind = %s, want assertion", testErr.Kind)
+	}
+}
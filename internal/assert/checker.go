@@ -0,0 +1,98 @@
+package assert
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/zero-day-ai/agents/debug/internal/runner"
+)
+
+// Expectation records the outcome of one check made through a Checker
+type Expectation struct {
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+	Kind   string `json:"kind,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Checker collects soft assertions for a single test: every expectation is
+// evaluated, and the test result reports all of the failures at once instead
+// of stopping at the first one
+//
+//	c := assert.NewChecker(testName, reqID, runner.CategorySDK)
+//	c.Check("subnet stored", assert.Equal(subnet, got))
+//	c.Check("hosts stored", assert.JSONEqual(hosts, gotHosts))
+//	return c.Result(time.Since(start), "Memory round-trip verified")
+type Checker struct {
+	testName      string
+	requirementID string
+	category      runner.Category
+
+	expectations []Expectation
+	failures     []error
+}
+
+// NewChecker creates a checker for the named test
+func NewChecker(testName, requirementID string, category runner.Category) *Checker {
+	return &Checker{
+		testName:      testName,
+		requirementID: requirementID,
+		category:      category,
+	}
+}
+
+// Check records an expectation and reports whether it held. err is typically
+// the outcome of an assertion, but harness and tool errors are accepted too
+// and keep their kind.
+func (c *Checker) Check(name string, err error) bool {
+	expectation := Expectation{Name: name, Passed: err == nil}
+	if err != nil {
+		expectation.Kind = string(runner.AsTestError(err).Kind)
+		expectation.Error = err.Error()
+		c.failures = append(c.failures, fmt.Errorf("%s: %w", name, err))
+	}
+	c.expectations = append(c.expectations, expectation)
+	return err == nil
+}
+
+// Failed reports whether any expectation failed
+func (c *Checker) Failed() bool {
+	return len(c.failures) > 0
+}
+
+// Expectations returns every recorded expectation in order
+func (c *Checker) Expectations() []Expectation {
+	return c.expectations
+}
+
+// Err returns nil if every expectation held, otherwise an error wrapping each
+// failure. Its kind is that of the first failure.
+func (c *Checker) Err() error {
+	if len(c.failures) == 0 {
+		return nil
+	}
+
+	verbs := make([]string, len(c.failures))
+	args := make([]any, 0, len(c.failures)+2)
+	args = append(args, len(c.failures), len(c.expectations))
+	for i, failure := range c.failures {
+		verbs[i] = "%w"
+		args = append(args, failure)
+	}
+	return fmt.Errorf("%d of %d expectations failed: "+strings.Join(verbs, "; "), args...)
+}
+
+// Result builds the test result: a pass with passMessage if every expectation
+// held, otherwise a failure listing all failed expectations. Every expectation
+// is recorded in Details["expectations"].
+func (c *Checker) Result(duration time.Duration, passMessage string) runner.TestResult {
+	var result runner.TestResult
+	if err := c.Err(); err != nil {
+		result = runner.NewFailResult(c.testName, c.requirementID, c.category, duration, err.Error(), err)
+	} else {
+		result = runner.NewPassResult(c.testName, c.requirementID, c.category, duration, passMessage)
+	}
+	result.Details["expectations"] = c.expectations
+	return result
+}
@@ -0,0 +1,68 @@
+package assert
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zero-day-ai/agents/debug/internal/runner"
+)
+
+func TestChecker_CollectsAllFailures(t *testing.T) {
+	refused := errors.New("connection refused")
+
+	c := NewChecker("Working Memory Storage", "NR-4", runner.CategorySDK)
+	c.Check("store scan_subnet", runner.HarnessError("Memory.Working.Set", refused))
+	c.Check("store live_hosts", nil)
+	c.Check("live_hosts round-trips", Len([]string{"10.0.0.1"}, 2))
+
+	result := c.Result(time.Second, "stored")
+
+	if result.Status != runner.TestStatusFail {
+		t.Fatalf("Status = %q, want fail", result.Status)
+	}
+	for _, want := range []string{
+		"2 of 3 expectations failed",
+		"store scan_subnet: connection refused",
+		"live_hosts round-trips: expected length 2, got 1",
+	} {
+		if !strings.Contains(result.Message, want) {
+			t.Errorf("Message = %q, want containing %q", result.Message, want)
+		}
+	}
+
+	// The first failure determines the kind, and every failure stays reachable
+	testErr := runner.AsTestError(result.Error)
+	if testErr.Kind != runner.ErrorKindHarness || testErr.HarnessCall != "Memory.Working.Set" {
+		t.Errorf("error = {%s %q}, want harness Memory.Working.Set", testErr.Kind, testErr.HarnessCall)
+	}
+	if !errors.Is(result.Error, refused) {
+		t.Error("error does not wrap the harness failure")
+	}
+
+	expectations, _ := result.Details["expectations"].([]Expectation)
+	want := []Expectation{
+		{Name: "store scan_subnet", Kind: "harness", Error: "connection refused"},
+		{Name: "store live_hosts", Passed: true},
+		{Name: "live_hosts round-trips", Kind: "assertion", Error: "expected length 2, got 1"},
+	}
+	if err := Equal(want, expectations); err != nil {
+		t.Errorf("Details[expectations]: %v", err)
+	}
+}
+
+func TestChecker_Pass(t *testing.T) {
+	c := NewChecker("Working Memory Storage", "NR-4", runner.CategorySDK)
+	if !c.Check("subnet", Equal("10.0.0.0/24", "10.0.0.0/24")) {
+		t.Error("Check() = false for a passing expectation")
+	}
+
+	result := c.Result(time.Second, "stored")
+	if result.Status != runner.TestStatusPass || result.Message != "stored" || result.Error != nil {
+		t.Errorf("result = %s %q %v, want pass", result.Status, result.Message, result.Error)
+	}
+	if c.Failed() || c.Err() != nil || len(c.Expectations()) != 1 {
+		t.Errorf("Failed() = %v, Err() = %v, %d expectations", c.Failed(), c.Err(), len(c.Expectations()))
+	}
+}
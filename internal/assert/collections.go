@@ -0,0 +1,255 @@
+package assert
+
+import (
+	"math"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Greater checks that got is greater than threshold. Both must be numbers.
+func Greater(got, threshold any) error {
+	g, t, err := toFloats(got, threshold)
+	if err != nil {
+		return err
+	}
+	if g <= t {
+		return failf("expected value greater than %v, got %v", threshold, got)
+	}
+	return nil
+}
+
+// Less checks that got is less than threshold. Both must be numbers.
+func Less(got, threshold any) error {
+	g, t, err := toFloats(got, threshold)
+	if err != nil {
+		return err
+	}
+	if g >= t {
+		return failf("expected value less than %v, got %v", threshold, got)
+	}
+	return nil
+}
+
+// InDelta checks that got is within an absolute tolerance of want
+func InDelta(want, got any, delta float64) error {
+	w, g, err := toFloats(want, got)
+	if err != nil {
+		return err
+	}
+	if diff := math.Abs(w - g); math.IsNaN(diff) || diff > delta {
+		return failf("expected %v ± %v, got %v (difference %v)", want, delta, got, diff)
+	}
+	return nil
+}
+
+// InEpsilon checks that got is within a relative tolerance of want
+// (e.g. 0.05 for 5%)
+func InEpsilon(want, got any, epsilon float64) error {
+	w, g, err := toFloats(want, got)
+	if err != nil {
+		return err
+	}
+	if w == 0 {
+		return InDelta(want, got, epsilon)
+	}
+	if rel := math.Abs(w-g) / math.Abs(w); math.IsNaN(rel) || rel > epsilon {
+		return failf("expected %v within %.2f%%, got %v (off by %.2f%%)", want, epsilon*100, got, rel*100)
+	}
+	return nil
+}
+
+// Contains checks that a string contains a substring, a slice or array contains
+// an element, or a map contains a key
+func Contains(container, element any) error {
+	found, err := contains(container, element)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return failf("%s does not contain %s", describe(container), formatValue(reflect.ValueOf(element)))
+	}
+	return nil
+}
+
+// NotContains checks that container does not contain element, as defined by Contains
+func NotContains(container, element any) error {
+	found, err := contains(container, element)
+	if err != nil {
+		return err
+	}
+	if found {
+		return failf("%s unexpectedly contains %s", describe(container), formatValue(reflect.ValueOf(element)))
+	}
+	return nil
+}
+
+// ContainsAll checks that container contains every element, reporting all missing ones
+func ContainsAll[T any](container any, elements ...T) error {
+	var missing []string
+	for _, element := range elements {
+		found, err := contains(container, element)
+		if err != nil {
+			return err
+		}
+		if !found {
+			missing = append(missing, formatValue(reflect.ValueOf(element)))
+		}
+	}
+	if len(missing) > 0 {
+		return failf("%s is missing %s", describe(container), strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// Len checks the length of a string, slice, array, map or channel
+func Len(collection any, want int) error {
+	n, err := length(collection)
+	if err != nil {
+		return err
+	}
+	if n != want {
+		return failf("expected length %d, got %d", want, n)
+	}
+	return nil
+}
+
+// Empty checks that a collection has no elements
+func Empty(collection any) error {
+	n, err := length(collection)
+	if err != nil {
+		return err
+	}
+	if n != 0 {
+		return failf("expected empty, got %s", describe(collection))
+	}
+	return nil
+}
+
+// NotEmpty checks that a collection has at least one element
+func NotEmpty(collection any) error {
+	n, err := length(collection)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return failf("expected non-empty %T", collection)
+	}
+	return nil
+}
+
+// ElementsMatch checks that two slices hold the same elements, ignoring order
+func ElementsMatch[T any](want, got []T) error {
+	remaining := slices.Clone(got)
+	var missing []string
+	for _, w := range want {
+		i := slices.IndexFunc(remaining, func(g T) bool { return reflect.DeepEqual(w, g) })
+		if i < 0 {
+			missing = append(missing, formatValue(reflect.ValueOf(w)))
+			continue
+		}
+		remaining = slices.Delete(remaining, i, i+1)
+	}
+	if len(missing) == 0 && len(remaining) == 0 {
+		return nil
+	}
+
+	var parts []string
+	if len(missing) > 0 {
+		parts = append(parts, "missing "+strings.Join(missing, ", "))
+	}
+	if len(remaining) > 0 {
+		extra := make([]string, len(remaining))
+		for i, g := range remaining {
+			extra[i] = formatValue(reflect.ValueOf(g))
+		}
+		parts = append(parts, "unexpected "+strings.Join(extra, ", "))
+	}
+	return failf("elements differ: %s", strings.Join(parts, "; "))
+}
+
+// contains reports whether container holds element
+func contains(container, element any) (bool, error) {
+	c := reflect.ValueOf(container)
+	switch c.Kind() {
+	case reflect.String:
+		s, ok := element.(string)
+		if !ok {
+			return false, failf("cannot look for %T in a string", element)
+		}
+		return strings.Contains(c.String(), s), nil
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < c.Len(); i++ {
+			if reflect.DeepEqual(c.Index(i).Interface(), element) {
+				return true, nil
+			}
+		}
+		return false, nil
+	case reflect.Map:
+		key := reflect.ValueOf(element)
+		if !key.IsValid() || !key.Type().AssignableTo(c.Type().Key()) {
+			return false, failf("cannot look for %T key in %T", element, container)
+		}
+		return c.MapIndex(key).IsValid(), nil
+	}
+	return false, failf("%T is not a string, slice, array or map", container)
+}
+
+// length returns the number of elements in a collection
+func length(collection any) (int, error) {
+	v := reflect.ValueOf(collection)
+	switch v.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map, reflect.Chan:
+		return v.Len(), nil
+	case reflect.Invalid:
+		return 0, nil
+	}
+	return 0, failf("%T has no length", collection)
+}
+
+// describe renders a collection for failure messages, truncating long values
+func describe(collection any) string {
+	s := formatValue(reflect.ValueOf(collection))
+	if len(s) > 200 {
+		s = s[:200] + "..."
+	}
+	return s
+}
+
+// toFloats converts two numbers to float64
+func toFloats(a, b any) (float64, float64, error) {
+	x, err := toFloat(a)
+	if err != nil {
+		return 0, 0, err
+	}
+	y, err := toFloat(b)
+	if err != nil {
+		return 0, 0, err
+	}
+	return x, y, nil
+}
+
+// toFloat converts any integer, unsigned or floating point value to float64
+func toFloat(value any) (float64, error) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), nil
+	}
+	return 0, failf("%s is not a number", formatValue(v))
+}
+
+// sortedKeys returns the keys of a JSON object in order
+func sortedKeys(object map[string]any) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
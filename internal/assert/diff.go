@@ -0,0 +1,129 @@
+package assert
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// maxDiffLines bounds the number of differences reported by Diff
+const maxDiffLines = 20
+
+// Diff describes the differences between want and got, one line per differing
+// path (e.g. `.Hosts[2].Ports[0]: want 443, got 8443`)
+func Diff(want, got any) string {
+	d := &differ{}
+	d.diff("", reflect.ValueOf(want), reflect.ValueOf(got))
+
+	if len(d.lines) == 0 {
+		// Values differ only in unexported state
+		return fmt.Sprintf("  want %v\n  got  %v", want, got)
+	}
+
+	lines := d.lines
+	if len(lines) > maxDiffLines {
+		lines = append(lines[:maxDiffLines:maxDiffLines], fmt.Sprintf("... and %d more differences", len(d.lines)-maxDiffLines))
+	}
+	return "  " + strings.Join(lines, "\n  ")
+}
+
+// differ collects differences between two values
+type differ struct {
+	lines []string
+}
+
+// report records a difference at a path
+func (d *differ) report(path, format string, args ...any) {
+	if path == "" {
+		path = "value"
+	}
+	d.lines = append(d.lines, path+": "+fmt.Sprintf(format, args...))
+}
+
+// diff walks want and got in parallel and records every difference
+func (d *differ) diff(path string, want, got reflect.Value) {
+	if !want.IsValid() || !got.IsValid() {
+		if want.IsValid() != got.IsValid() {
+			d.report(path, "want %s, got %s", formatValue(want), formatValue(got))
+		}
+		return
+	}
+	if want.Type() != got.Type() {
+		d.report(path, "want %s (%s), got %s (%s)", formatValue(want), want.Type(), formatValue(got), got.Type())
+		return
+	}
+
+	switch want.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if want.IsNil() || got.IsNil() {
+			if want.IsNil() != got.IsNil() {
+				d.report(path, "want %s, got %s", formatValue(want), formatValue(got))
+			}
+			return
+		}
+		d.diff(path, want.Elem(), got.Elem())
+
+	case reflect.Struct:
+		for i := 0; i < want.NumField(); i++ {
+			if !want.Type().Field(i).IsExported() {
+				continue
+			}
+			d.diff(path+"."+want.Type().Field(i).Name, want.Field(i), got.Field(i))
+		}
+
+	case reflect.Map:
+		keys := append(want.MapKeys(), got.MapKeys()...)
+		slices.SortFunc(keys, func(a, b reflect.Value) int {
+			return cmp.Compare(fmt.Sprint(a.Interface()), fmt.Sprint(b.Interface()))
+		})
+		keys = slices.CompactFunc(keys, func(a, b reflect.Value) bool {
+			return fmt.Sprint(a.Interface()) == fmt.Sprint(b.Interface())
+		})
+		for _, key := range keys {
+			keyPath := fmt.Sprintf("%s[%s]", path, formatValue(key))
+			w, g := want.MapIndex(key), got.MapIndex(key)
+			switch {
+			case !g.IsValid():
+				d.report(keyPath, "missing, want %s", formatValue(w))
+			case !w.IsValid():
+				d.report(keyPath, "unexpected %s", formatValue(g))
+			default:
+				d.diff(keyPath, w, g)
+			}
+		}
+
+	case reflect.Slice, reflect.Array:
+		if want.Kind() == reflect.Slice && want.IsNil() != got.IsNil() && want.Len() == 0 && got.Len() == 0 {
+			d.report(path, "want %s, got %s", nilOrEmpty(want), nilOrEmpty(got))
+			return
+		}
+		n := min(want.Len(), got.Len())
+		for i := 0; i < n; i++ {
+			d.diff(fmt.Sprintf("%s[%d]", path, i), want.Index(i), got.Index(i))
+		}
+		for i := n; i < want.Len(); i++ {
+			d.report(fmt.Sprintf("%s[%d]", path, i), "missing, want %s", formatValue(want.Index(i)))
+		}
+		for i := n; i < got.Len(); i++ {
+			d.report(fmt.Sprintf("%s[%d]", path, i), "unexpected %s", formatValue(got.Index(i)))
+		}
+
+	default:
+		if !want.CanInterface() || !got.CanInterface() {
+			return
+		}
+		if !reflect.DeepEqual(want.Interface(), got.Interface()) {
+			d.report(path, "want %s, got %s", formatValue(want), formatValue(got))
+		}
+	}
+}
+
+// nilOrEmpty describes an empty slice as nil or empty
+func nilOrEmpty(v reflect.Value) string {
+	if v.IsNil() {
+		return "nil"
+	}
+	return "empty " + v.Type().String()
+}
@@ -0,0 +1,32 @@
+package assert
+
+import (
+	"context"
+	"time"
+)
+
+// Eventually polls condition every interval until it returns nil, for stores
+// that become consistent asynchronously (e.g. GraphRAG indexing). It fails with
+// the last condition error once timeout elapses or ctx is cancelled.
+func Eventually(ctx context.Context, timeout, interval time.Duration, condition func(ctx context.Context) error) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	start := time.Now()
+	for attempts := 1; ; attempts++ {
+		err := condition(ctx)
+		if err == nil {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return failf("condition not met after %d attempts in %v: %w (%w)",
+				attempts, time.Since(start).Round(time.Millisecond), err, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
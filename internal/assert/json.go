@@ -0,0 +1,225 @@
+package assert
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/zero-day-ai/sdk/schema"
+)
+
+// normalizeJSON converts a value to its generic JSON form (maps, slices, float64,
+// strings, booleans and nil). Raw JSON given as []byte or json.RawMessage is parsed.
+func normalizeJSON(value any) (any, error) {
+	var data []byte
+	switch v := value.(type) {
+	case json.RawMessage:
+		data = v
+	case []byte:
+		data = v
+	default:
+		var err error
+		if data, err = json.Marshal(value); err != nil {
+			return nil, fmt.Errorf("value is not JSON serializable: %w", err)
+		}
+	}
+
+	var normalized any
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	return normalized, nil
+}
+
+// JSONEqual checks that want and got are equal as JSON documents, ignoring Go types,
+// field order and formatting. Either side may be raw JSON ([]byte) or any value.
+func JSONEqual(want, got any) error {
+	w, err := normalizeJSON(want)
+	if err != nil {
+		return failf("expected value: %w", err)
+	}
+	g, err := normalizeJSON(got)
+	if err != nil {
+		return failf("actual value: %w", err)
+	}
+	return Equal(w, g)
+}
+
+// JSONPath evaluates a path against the JSON form of doc. Supported syntax is
+// the common subset `$.hosts[0].ports[*].number`: member access with `.name` or
+// `['name']`, array indexes (negative from the end) and the `*` wildcard. Paths
+// containing a wildcard return a slice of every match.
+func JSONPath(doc any, path string) (any, error) {
+	steps, err := parseJSONPath(path)
+	if err != nil {
+		return nil, err
+	}
+	root, err := normalizeJSON(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := []any{root}
+	wildcard := false
+	for _, step := range steps {
+		var next []any
+		for _, node := range matches {
+			found, err := step.apply(node)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", path, err)
+			}
+			next = append(next, found...)
+		}
+		matches = next
+		wildcard = wildcard || step.wildcard
+	}
+
+	if wildcard {
+		if matches == nil {
+			matches = []any{}
+		}
+		return matches, nil
+	}
+	return matches[0], nil
+}
+
+// JSONPathEqual checks that the value at path in doc equals want as JSON
+func JSONPathEqual(doc any, path string, want any) error {
+	got, err := JSONPath(doc, path)
+	if err != nil {
+		return failf("%w", err)
+	}
+	if err := JSONEqual(want, got); err != nil {
+		return failf("%s: %w", path, err)
+	}
+	return nil
+}
+
+// JSONPathExists checks that path resolves in doc
+func JSONPathExists(doc any, path string) error {
+	if _, err := JSONPath(doc, path); err != nil {
+		return failf("%w", err)
+	}
+	return nil
+}
+
+// MatchesSchema validates the JSON form of value (e.g. a tool output) against a
+// JSON Schema
+func MatchesSchema(s schema.JSON, value any) error {
+	normalized, err := normalizeJSON(value)
+	if err != nil {
+		return failf("%w", err)
+	}
+	if err := s.Validate(normalized); err != nil {
+		return failf("does not match schema: %w", err)
+	}
+	return nil
+}
+
+// jsonPathStep is one member, index or wildcard access
+type jsonPathStep struct {
+	key      string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+// apply returns the nodes selected by the step from node
+func (s jsonPathStep) apply(node any) ([]any, error) {
+	switch {
+	case s.wildcard:
+		switch v := node.(type) {
+		case []any:
+			return v, nil
+		case map[string]any:
+			keys := sortedKeys(v)
+			values := make([]any, len(keys))
+			for i, key := range keys {
+				values[i] = v[key]
+			}
+			return values, nil
+		}
+		return nil, fmt.Errorf("wildcard applied to %T", node)
+
+	case s.isIndex:
+		array, ok := node.([]any)
+		if !ok {
+			return nil, fmt.Errorf("index [%d] applied to %T", s.index, node)
+		}
+		i := s.index
+		if i < 0 {
+			i += len(array)
+		}
+		if i < 0 || i >= len(array) {
+			return nil, fmt.Errorf("index [%d] out of range (length %d)", s.index, len(array))
+		}
+		return []any{array[i]}, nil
+
+	default:
+		object, ok := node.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("member %q applied to %T", s.key, node)
+		}
+		value, ok := object[s.key]
+		if !ok {
+			return nil, fmt.Errorf("member %q not found", s.key)
+		}
+		return []any{value}, nil
+	}
+}
+
+// parseJSONPath splits a path into steps
+func parseJSONPath(path string) ([]jsonPathStep, error) {
+	rest, ok := strings.CutPrefix(path, "$")
+	if !ok {
+		return nil, fmt.Errorf("JSONPath %q must start with $", path)
+	}
+
+	var steps []jsonPathStep
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			name := rest[:end]
+			rest = rest[end:]
+			switch name {
+			case "":
+				return nil, fmt.Errorf("JSONPath %q has an empty member name", path)
+			case "*":
+				steps = append(steps, jsonPathStep{wildcard: true})
+			default:
+				steps = append(steps, jsonPathStep{key: name})
+			}
+
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("JSONPath %q has an unterminated [", path)
+			}
+			selector := rest[1:end]
+			rest = rest[end+1:]
+
+			switch {
+			case selector == "*":
+				steps = append(steps, jsonPathStep{wildcard: true})
+			case len(selector) >= 2 && (selector[0] == '\'' || selector[0] == '"') && selector[len(selector)-1] == selector[0]:
+				steps = append(steps, jsonPathStep{key: selector[1 : len(selector)-1]})
+			default:
+				index, err := strconv.Atoi(selector)
+				if err != nil {
+					return nil, fmt.Errorf("JSONPath %q has an invalid index [%s]", path, selector)
+				}
+				steps = append(steps, jsonPathStep{index: index, isIndex: true})
+			}
+
+		default:
+			return nil, fmt.Errorf("JSONPath %q: unexpected %q", path, rest[0])
+		}
+	}
+	return steps, nil
+}
@@ -0,0 +1,102 @@
+package assert
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/zero-day-ai/sdk/schema"
+)
+
+const nmapOutput = `{
+	"hosts": [
+		{"ip": "10.0.0.1", "ports": [{"port": 22, "state": "open"}, {"port": 80, "state": "closed"}]},
+		{"ip": "10.0.0.2", "ports": [{"port": 443, "state": "open"}]}
+	],
+	"stats": {"up": 2, "total": 256}
+}`
+
+func TestJSONPath(t *testing.T) {
+	tests := []struct {
+		path    string
+		want    string
+		wantErr string
+	}{
+		{path: "$", want: nmapOutput},
+		{path: "$.stats.up", want: `2`},
+		{path: "$.hosts[1].ip", want: `"10.0.0.2"`},
+		{path: "$['hosts'][-1]['ip']", want: `"10.0.0.2"`},
+		{path: "$.hosts[*].ip", want: `["10.0.0.1", "10.0.0.2"]`},
+		{path: "$.hosts[*].ports[*].port", want: `[22, 80, 443]`},
+		{path: "$.stats.*", want: `[256, 2]`},
+		{path: "$.hosts[2]", wantErr: "index [2] out of range (length 2)"},
+		{path: "$.hosts.ip", wantErr: `member "ip" applied to []interface {}`},
+		{path: "$.missing", wantErr: `member "missing" not found`},
+		{path: "hosts", wantErr: "must start with $"},
+		{path: "$.hosts[x]", wantErr: "invalid index [x]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, err := JSONPath([]byte(nmapOutput), tt.path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("JSONPath() error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("JSONPath() unexpected error: %v", err)
+			}
+			if err := JSONEqual([]byte(tt.want), got); err != nil {
+				t.Errorf("JSONPath() mismatch: %v", err)
+			}
+		})
+	}
+}
+
+func TestJSONEqual(t *testing.T) {
+	type stats struct {
+		Up    int `json:"up"`
+		Total int `json:"total"`
+	}
+
+	if err := JSONEqual(`{"total": 256, "up": 2}`, stats{Up: 2, Total: 256}); err == nil {
+		t.Error("JSONEqual() compared a JSON string literal as a document")
+	}
+	if err := JSONEqual([]byte(`{"total": 256, "up": 2}`), stats{Up: 2, Total: 256}); err != nil {
+		t.Errorf("JSONEqual() unexpected error: %v", err)
+	}
+
+	err := JSONPathEqual([]byte(nmapOutput), "$.stats", stats{Up: 3, Total: 256})
+	if err == nil || !strings.Contains(err.Error(), `["up"]: want 3, got 2`) {
+		t.Errorf("JSONPathEqual() error = %v, want field diff", err)
+	}
+	if err := JSONPathExists([]byte(nmapOutput), "$.hosts[0].ports[1].state"); err != nil {
+		t.Errorf("JSONPathExists() unexpected error: %v", err)
+	}
+}
+
+func TestMatchesSchema(t *testing.T) {
+	portSchema := schema.Object(map[string]schema.JSON{
+		"port":  schema.Int(),
+		"state": schema.Enum("open", "closed", "filtered"),
+	}, "port", "state")
+	outputSchema := schema.Object(map[string]schema.JSON{
+		"hosts": schema.Array(schema.Object(map[string]schema.JSON{
+			"ip":    schema.String(),
+			"ports": schema.Array(portSchema),
+		}, "ip")),
+	}, "hosts")
+
+	if err := MatchesSchema(outputSchema, []byte(nmapOutput)); err != nil {
+		t.Errorf("MatchesSchema() unexpected error: %v", err)
+	}
+
+	invalid := map[string]any{
+		"hosts": []map[string]any{{"ip": "10.0.0.1", "ports": []map[string]any{{"port": 22, "state": "unknown"}}}},
+	}
+	err := MatchesSchema(outputSchema, invalid)
+	if err == nil || !strings.Contains(err.Error(), "does not match schema") {
+		t.Errorf("MatchesSchema() error = %v, want schema violation", err)
+	}
+}
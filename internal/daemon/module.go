@@ -0,0 +1,278 @@
+// Package daemon tests the Gibson daemon: the HarnessCallbackService it serves
+// at its gRPC address, and the mission lifecycle it exposes to agents through
+// the harness (CreateMission, RunMission, GetMissionStatus, ListMissions,
+// CancelMission and WaitForMission).
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zero-day-ai/sdk/agent"
+	"github.com/zero-day-ai/sdk/api/gen/proto"
+	"github.com/zero-day-ai/sdk/mission"
+	"github.com/zero-day-ai/sdk/serve"
+
+	"github.com/zero-day-ai/agents/debug/internal/assert"
+	"github.com/zero-day-ai/agents/debug/internal/runner"
+)
+
+// requirementID is the requirement the daemon service tests validate
+const requirementID = "17"
+
+// AddressEnv holds the daemon gRPC address used when none is configured
+const AddressEnv = "GIBSON_DAEMON_ADDRESS"
+
+// missionTag tags the missions the tests create, so they can be found and
+// told apart from real missions
+const missionTag = "debug-daemon"
+
+// missionTimeout bounds the wait for a cancelled mission to end
+const missionTimeout = 30 * time.Second
+
+// Module tests the daemon's gRPC service at a configured address and the
+// mission lifecycle through the harness
+type Module struct {
+	address string
+}
+
+// NewModule creates the daemon service test module. The connection test uses
+// the daemon at address (GIBSON_DAEMON_ADDRESS when empty) and is skipped
+// without one; the mission tests go through the harness.
+func NewModule(address string) *Module {
+	if address == "" {
+		address = os.Getenv(AddressEnv)
+	}
+	return &Module{address: address}
+}
+
+func (m *Module) Name() string {
+	return "daemon"
+}
+
+func (m *Module) Description() string {
+	return "Daemon service: gRPC connection, RunMission, CancelMission, ListMissions and mission status"
+}
+
+func (m *Module) Category() runner.Category {
+	return runner.CategoryFramework
+}
+
+func (m *Module) RequirementID() string {
+	return requirementID
+}
+
+// TestCases returns the daemon service tests
+func (m *Module) TestCases() []runner.TestCase {
+	return []runner.TestCase{
+		{
+			ID:            "connect",
+			Name:          "Daemon Service: Connect",
+			RequirementID: requirementID,
+			Tags:          []string{"daemon"},
+			Run: func(ctx context.Context, h agent.Harness) runner.TestResult {
+				return m.testConnect(ctx, "Daemon Service: Connect")
+			},
+		},
+		missionCase("run-cancel", "Daemon Service: RunMission and CancelMission", testRunCancel),
+		missionCase("list-missions", "Daemon Service: ListMissions", testListMissions),
+		missionCase("mission-status", "Daemon Service: Mission Status", testMissionStatus),
+	}
+}
+
+// Run executes the daemon service tests
+func (m *Module) Run(ctx context.Context, h agent.Harness) []runner.TestResult {
+	return runner.RunCases(ctx, h, m, m.TestCases())
+}
+
+// testConnect connects to the daemon's HarnessCallbackService and lists the
+// agents it knows
+func (m *Module) testConnect(ctx context.Context, testName string) runner.TestResult {
+	startTime := time.Now()
+	if m.address == "" {
+		return runner.NewSkipResult(testName, requirementID, runner.CategoryFramework,
+			fmt.Sprintf("No daemon address configured (set daemon_address or %s)", AddressEnv))
+	}
+
+	client, err := serve.NewCallbackClient(m.address)
+	if err != nil {
+		return runner.NewErrorResult(testName, requirementID, runner.CategoryFramework, time.Since(startTime), err)
+	}
+	defer client.Close()
+	if err := client.Connect(ctx); err != nil {
+		return runner.NewFailResult(testName, requirementID, runner.CategoryFramework, time.Since(startTime),
+			fmt.Sprintf("Failed to connect to the daemon at %s", m.address), err)
+	}
+
+	resp, err := client.ListAgents(ctx, &proto.ListAgentsRequest{})
+	if err != nil {
+		return runner.NewFailResult(testName, requirementID, runner.CategoryFramework, time.Since(startTime),
+			"ListAgents failed", err)
+	}
+	if resp.GetError() != nil {
+		return runner.NewFailResult(testName, requirementID, runner.CategoryFramework, time.Since(startTime),
+			"ListAgents returned an error", fmt.Errorf("%s: %s", resp.GetError().GetCode(), resp.GetError().GetMessage()))
+	}
+
+	names := make([]string, 0, len(resp.GetAgents()))
+	for _, a := range resp.GetAgents() {
+		names = append(names, a.GetName())
+	}
+	return runner.NewPassResult(testName, requirementID, runner.CategoryFramework, time.Since(startTime),
+		fmt.Sprintf("Daemon at %s serves %d agents", m.address, len(names))).
+		WithDetails(map[string]any{"address": m.address, "agents": names})
+}
+
+// missionTest runs one test against a mission the test case created and started
+type missionTest func(ctx context.Context, h agent.Harness, testName string, info *mission.MissionInfo) runner.TestResult
+
+// missionCase creates a test case that creates and runs a probe mission through
+// the harness. The mission is cancelled when the test ends, if it still runs.
+func missionCase(id, testName string, test missionTest) runner.TestCase {
+	return runner.TestCase{
+		ID:            id,
+		Name:          testName,
+		RequirementID: requirementID,
+		Tags:          []string{"daemon"},
+		Run: func(ctx context.Context, h agent.Harness) runner.TestResult {
+			startTime := time.Now()
+			name := "debug-daemon-" + uuid.NewString()
+			info, err := h.CreateMission(ctx, probeWorkflow(name), h.Target().ID, &mission.CreateMissionOpts{
+				Name:        name,
+				Tags:        []string{missionTag},
+				Constraints: &mission.MissionConstraints{MaxDuration: time.Minute},
+			})
+			if err != nil {
+				return runner.NewFailResult(testName, requirementID, runner.CategoryFramework, time.Since(startTime),
+					"CreateMission failed", err)
+			}
+			if err := h.RunMission(ctx, info.ID, nil); err != nil {
+				return runner.NewFailResult(testName, requirementID, runner.CategoryFramework, time.Since(startTime),
+					"RunMission failed", err)
+			}
+			defer func() {
+				if status, err := h.GetMissionStatus(ctx, info.ID); err == nil && !status.Status.IsTerminal() {
+					_ = h.CancelMission(ctx, info.ID)
+				}
+			}()
+			return test(ctx, h, testName, info)
+		},
+	}
+}
+
+// probeWorkflow is the mission the tests create: one health check of the debug
+// agent, cheap to run should a cancellation arrive late
+func probeWorkflow(name string) map[string]any {
+	return map[string]any{
+		"name": name,
+		"nodes": []any{
+			map[string]any{
+				"id":    "health",
+				"type":  "agent",
+				"agent": "debug-agent",
+				"task": map[string]any{
+					"context": map[string]any{"mode": "health-check", "component": "memory"},
+				},
+			},
+		},
+	}
+}
+
+// cancelAndWait cancels a mission and waits for it to end
+func cancelAndWait(ctx context.Context, h agent.Harness, missionID string) (*mission.MissionResult, error) {
+	if err := h.CancelMission(ctx, missionID); err != nil {
+		return nil, fmt.Errorf("CancelMission failed: %w", err)
+	}
+	result, err := h.WaitForMission(ctx, missionID, missionTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("WaitForMission failed: %w", err)
+	}
+	return result, nil
+}
+
+// testRunCancel checks that a started mission can be cancelled
+func testRunCancel(ctx context.Context, h agent.Harness, testName string, info *mission.MissionInfo) runner.TestResult {
+	startTime := time.Now()
+	result, err := cancelAndWait(ctx, h, info.ID)
+	if err != nil {
+		return runner.NewFailResult(testName, requirementID, runner.CategoryFramework, time.Since(startTime),
+			"Mission could not be cancelled", err)
+	}
+
+	ch := assert.NewChecker(testName, requirementID, runner.CategoryFramework)
+	ch.Check("created status", assert.Contains([]mission.MissionStatus{mission.MissionStatusPending, mission.MissionStatusRunning}, info.Status))
+	ch.Check("result mission ID", assert.Equal(info.ID, result.MissionID))
+	ch.Check("result status", assert.Equal(mission.MissionStatusCancelled, result.Status))
+	return ch.Result(time.Since(startTime), fmt.Sprintf("Mission %s started and cancelled", info.ID))
+}
+
+// testListMissions checks that a mission is listed under its tag while it runs
+// and under the cancelled status once it ended
+func testListMissions(ctx context.Context, h agent.Harness, testName string, info *mission.MissionInfo) runner.TestResult {
+	startTime := time.Now()
+	tagged, listErr := listIDs(ctx, h, &mission.MissionFilter{Tags: []string{missionTag}})
+	if _, err := cancelAndWait(ctx, h, info.ID); err != nil {
+		return runner.NewFailResult(testName, requirementID, runner.CategoryFramework, time.Since(startTime),
+			"Mission could not be cancelled", err)
+	}
+	if listErr != nil {
+		return runner.NewFailResult(testName, requirementID, runner.CategoryFramework, time.Since(startTime),
+			"ListMissions failed", listErr)
+	}
+	cancelled := mission.MissionStatusCancelled
+	ended, err := listIDs(ctx, h, &mission.MissionFilter{Status: &cancelled, Tags: []string{missionTag}})
+	if err != nil {
+		return runner.NewFailResult(testName, requirementID, runner.CategoryFramework, time.Since(startTime),
+			"ListMissions failed", err)
+	}
+
+	ch := assert.NewChecker(testName, requirementID, runner.CategoryFramework)
+	ch.Check("listed by tag", assert.Contains(tagged, info.ID))
+	ch.Check("listed as cancelled", assert.Contains(ended, info.ID))
+	return ch.Result(time.Since(startTime), fmt.Sprintf("Mission %s listed while running, then as cancelled", info.ID))
+}
+
+// listIDs returns the IDs of the missions matching filter
+func listIDs(ctx context.Context, h agent.Harness, filter *mission.MissionFilter) ([]string, error) {
+	missions, err := h.ListMissions(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(missions))
+	for _, info := range missions {
+		ids = append(ids, info.ID)
+	}
+	return ids, nil
+}
+
+// testMissionStatus follows a mission's status from running to its end. The
+// SDK has no mission event subscription, so transitions are observed through
+// GetMissionStatus and WaitForMission.
+func testMissionStatus(ctx context.Context, h agent.Harness, testName string, info *mission.MissionInfo) runner.TestResult {
+	startTime := time.Now()
+	running, err := h.GetMissionStatus(ctx, info.ID)
+	if err != nil {
+		return runner.NewFailResult(testName, requirementID, runner.CategoryFramework, time.Since(startTime),
+			"GetMissionStatus failed", err)
+	}
+	result, err := cancelAndWait(ctx, h, info.ID)
+	if err != nil {
+		return runner.NewFailResult(testName, requirementID, runner.CategoryFramework, time.Since(startTime),
+			"Mission could not be cancelled", err)
+	}
+	ended, err := h.GetMissionStatus(ctx, info.ID)
+	if err != nil {
+		return runner.NewFailResult(testName, requirementID, runner.CategoryFramework, time.Since(startTime),
+			"GetMissionStatus failed", err)
+	}
+
+	ch := assert.NewChecker(testName, requirementID, runner.CategoryFramework)
+	ch.Check("status while running", assert.True(running.Status.IsValid() && !running.Status.IsTerminal(),
+		"mission status before cancelling is %q, want pending, running or paused", running.Status))
+	ch.Check("status after cancel", assert.Equal(mission.MissionStatusCancelled, ended.Status))
+	ch.Check("status matches result", assert.Equal(result.Status, ended.Status))
+	return ch.Result(time.Since(startTime), fmt.Sprintf("Mission %s went from %s to %s", info.ID, running.Status, ended.Status))
+}
@@ -10,6 +10,10 @@ import (
 	"github.com/zero-day-ai/agents/debug/internal/runner"
 )
 
+// childMode is the mode that makes a delegated debug agent echo its task back
+// instead of running the test suite (and delegating again)
+const childMode = "child"
+
 // TestConfig holds configuration for delegation tests
 type TestConfig struct {
 	// TargetAgent is the agent to delegate to (default: "debug-agent")
@@ -48,7 +52,11 @@ func ExecuteDelegationTest(ctx context.Context, harness agent.Harness, cfg TestC
 
 	// Create delegation task with test context
 	delegationTask := agent.Task{
-		ID: fmt.Sprintf("delegation-test-%d", time.Now().Unix()),
+		ID:   fmt.Sprintf("delegation-test-%d", time.Now().Unix()),
+		Goal: "Echo the delegated task back to the parent",
+		Context: map[string]any{
+			"mode": childMode,
+		},
 		Metadata: map[string]any{
 			"test_message": cfg.TestMessage,
 			"test_type":    "delegation",
@@ -0,0 +1,58 @@
+package delegation
+
+import (
+	"context"
+	"time"
+
+	"github.com/zero-day-ai/sdk/agent"
+
+	"github.com/zero-day-ai/agents/debug/internal/runner"
+)
+
+// delegationTimeout covers starting the child agent and its round-trip
+const delegationTimeout = time.Minute
+
+// Module tests delegating a task to another debug agent
+type Module struct{}
+
+// NewModule creates the delegation test module
+func NewModule() *Module {
+	return &Module{}
+}
+
+func (m *Module) Name() string {
+	return "delegation"
+}
+
+func (m *Module) Description() string {
+	return "Task delegation to a child debug agent"
+}
+
+func (m *Module) Category() runner.Category {
+	return runner.CategorySDK
+}
+
+func (m *Module) RequirementID() string {
+	return "REQ-6"
+}
+
+// TestCases returns the delegation test
+func (m *Module) TestCases() []runner.TestCase {
+	return []runner.TestCase{
+		{
+			ID:            "delegate",
+			Name:          "Agent Delegation Test",
+			RequirementID: m.RequirementID(),
+			Tags:          []string{"delegation"},
+			Timeout:       delegationTimeout,
+			Run: func(ctx context.Context, h agent.Harness) runner.TestResult {
+				return ExecuteDelegationTest(ctx, h, TestConfig{})
+			},
+		},
+	}
+}
+
+// Run executes the delegation test
+func (m *Module) Run(ctx context.Context, h agent.Harness) []runner.TestResult {
+	return runner.RunCases(ctx, h, m, m.TestCases())
+}
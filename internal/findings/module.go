@@ -0,0 +1,92 @@
+package findings
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/zero-day-ai/sdk/agent"
+
+	"github.com/zero-day-ai/agents/debug/internal/runner"
+)
+
+// requirementID is the requirement the findings round-trip validates
+const requirementID = "REQ-7"
+
+// Module tests that a submitted finding can be read back
+type Module struct {
+	cfg Config
+}
+
+// NewModule creates the findings test module. prefix marks the title of the
+// test finding (default "[DEBUG]").
+func NewModule(prefix string) *Module {
+	return &Module{cfg: Config{Prefix: prefix}}
+}
+
+func (m *Module) Name() string {
+	return "findings"
+}
+
+func (m *Module) Description() string {
+	return "Finding submission and retrieval round-trip"
+}
+
+func (m *Module) Category() runner.Category {
+	return runner.CategorySDK
+}
+
+func (m *Module) RequirementID() string {
+	return requirementID
+}
+
+// TestCases returns the findings round-trip test
+func (m *Module) TestCases() []runner.TestCase {
+	testName := "Findings Round-Trip"
+	return []runner.TestCase{
+		{
+			ID:            "round-trip",
+			Name:          testName,
+			RequirementID: requirementID,
+			Tags:          []string{"findings"},
+			Run: func(ctx context.Context, h agent.Harness) runner.TestResult {
+				startTime := time.Now()
+				res, err := ExecuteFindingsTest(ctx, h, &m.cfg)
+				if err != nil {
+					return runner.NewErrorResult(testName, requirementID, runner.CategorySDK, time.Since(startTime), err)
+				}
+				return testResult(testName, res, time.Since(startTime))
+			},
+		},
+	}
+}
+
+// Run executes the findings test
+func (m *Module) Run(ctx context.Context, h agent.Harness) []runner.TestResult {
+	return runner.RunCases(ctx, h, m, m.TestCases())
+}
+
+// testResult converts the agent result of ExecuteFindingsTest into a runner
+// test result. A finding that was submitted but not read back fails the test.
+func testResult(testName string, res agent.Result, duration time.Duration) runner.TestResult {
+	var outcome TestResult
+	message, _ := res.Output.(string)
+	if json.Unmarshal([]byte(message), &outcome) == nil && outcome.Message != "" {
+		message = outcome.Message
+	}
+
+	details := map[string]any{
+		"status":     outcome.Status,
+		"finding_id": outcome.FindingID,
+	}
+	if res.Status == agent.StatusSuccess {
+		return runner.NewPassResult(testName, requirementID, runner.CategorySDK, duration, message).WithDetails(details)
+	}
+
+	err := res.Error
+	if err == nil {
+		err = fmt.Errorf("finding round-trip incomplete: %s", outcome.Status)
+	}
+	return runner.NewFailResult(testName, requirementID, runner.CategorySDK, duration, message, err).WithDetails(details)
+}
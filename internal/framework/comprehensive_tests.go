@@ -6,11 +6,12 @@ import (
 
 	"github.com/zero-day-ai/sdk/agent"
 
+	"github.com/zero-day-ai/agents/debug/internal/assert"
 	"github.com/zero-day-ai/agents/debug/internal/runner"
 )
 
 // ComprehensiveFrameworkModule tests framework functionality
-// This consolidates tests from Requirements 17-31 for efficiency
+// This consolidates tests from Requirements 18-31 for efficiency
 type ComprehensiveFrameworkModule struct {
 	BaseModule
 }
@@ -20,65 +21,58 @@ func NewComprehensiveFrameworkModule() *ComprehensiveFrameworkModule {
 	return &ComprehensiveFrameworkModule{
 		BaseModule: NewBaseModule(
 			"comprehensive-framework",
-			"Comprehensive Framework tests covering daemon, mission orchestration, workflow engine, database, and observability",
-			"17-31",
+			"Comprehensive Framework tests covering mission orchestration, workflow engine, database, and observability",
+			"18-31",
 		),
 	}
 }
 
-// Run executes all Framework tests
-func (m *ComprehensiveFrameworkModule) Run(ctx context.Context, h agent.Harness) []runner.TestResult {
-	results := []runner.TestResult{}
+// TestCases returns the framework checks as individually addressable tests
+func (m *ComprehensiveFrameworkModule) TestCases() []runner.TestCase {
+	cases := []runner.TestCase{}
 
 	// Note: Most framework tests require a running Gibson daemon
-	// For now, we'll create placeholder tests that document what should be tested
+	// Checks that do not exist yet report not_implemented, so the requirement
+	// shows as unverified instead of counting toward the pass rate
 
-	// Requirement 17: Daemon Service
-	results = append(results, m.testDaemonService(ctx, h)...)
+	// Requirement 17 (daemon service) is tested by the daemon module
 
 	// Requirement 18: Mission Orchestration
-	results = append(results, m.testMissionOrchestration(ctx, h)...)
+	cases = append(cases, m.testMissionOrchestration()...)
 
 	// Requirement 19: Workflow Engine
-	results = append(results, m.testWorkflowEngine(ctx, h)...)
+	cases = append(cases, m.testWorkflowEngine()...)
 
-	// Requirement 20: Component Registry
-	results = append(results, m.testComponentRegistry(ctx, h)...)
+	// Requirement 20 (component registry) is tested by the registry module
 
 	// Requirement 21: Database Layer
-	results = append(results, m.testDatabaseLayer(ctx, h)...)
+	cases = append(cases, m.testDatabaseLayer()...)
 
 	// Requirements 22-31: Other framework components
-	results = append(results, m.testOtherComponents(ctx, h)...)
+	cases = append(cases, m.testOtherComponents()...)
 
-	return results
+	return cases
 }
 
-// testDaemonService tests daemon gRPC service (Req 17)
-func (m *ComprehensiveFrameworkModule) testDaemonService(ctx context.Context, h agent.Harness) []runner.TestResult {
-	results := []runner.TestResult{}
-	testName := "Daemon Service"
-	reqID := "17"
-
-	// Framework tests require daemon connectivity
-	// For a complete implementation, we would:
-	// 1. Connect to daemon via gRPC
-	// 2. Test RunMission, StopMission, ListMissions, etc.
-	// 3. Test Subscribe for event streaming
-	// 4. Test component lifecycle RPCs
-
-	results = append(results, SkipTest(testName, reqID,
-		"Daemon service tests require running Gibson daemon - skipped in current implementation"))
-
-	results = append(results, PassTest(testName+": Design Complete", reqID,
-		"Daemon gRPC service test design is documented (requires daemon for execution)"))
+// Run executes all Framework tests
+func (m *ComprehensiveFrameworkModule) Run(ctx context.Context, h agent.Harness) []runner.TestResult {
+	return runner.RunCases(ctx, h, m, m.TestCases())
+}
 
-	return results
+// staticCase creates a test case whose result does not depend on the harness
+func staticCase(id, name, reqID string, result runner.TestResult) runner.TestCase {
+	return runner.TestCase{
+		ID:            id,
+		Name:          name,
+		RequirementID: reqID,
+		Run: func(ctx context.Context, h agent.Harness) runner.TestResult {
+			return result
+		},
+	}
 }
 
 // testMissionOrchestration tests mission lifecycle (Req 18)
-func (m *ComprehensiveFrameworkModule) testMissionOrchestration(ctx context.Context, h agent.Harness) []runner.TestResult {
-	results := []runner.TestResult{}
+func (m *ComprehensiveFrameworkModule) testMissionOrchestration() []runner.TestCase {
 	testName := "Mission Orchestration"
 	reqID := "18"
 
@@ -88,61 +82,48 @@ func (m *ComprehensiveFrameworkModule) testMissionOrchestration(ctx context.Cont
 	// - Memory continuity modes
 	// - Constraint enforcement
 
-	// For now, we can verify mission context is available
-	mission := h.Mission()
-	if mission.ID != "" {
-		results = append(results, PassTest(testName+": Context Available", reqID,
-			fmt.Sprintf("Mission orchestration context is available - Mission ID: %s", mission.ID)))
+	return []runner.TestCase{
+		{
+			// For now, we can verify mission context is available
+			ID:            "mission-context",
+			Name:          testName + ": Context Available",
+			RequirementID: reqID,
+			Tags:          []string{"mission"},
+			Run: func(ctx context.Context, h agent.Harness) runner.TestResult {
+				mission := h.Mission()
+				if mission.ID == "" {
+					return SkipTest(testName+": Context Available", reqID,
+						"Mission context not available")
+				}
+				// The daemon exchanges the context as JSON, so it must round-trip with its ID
+				return Check(testName+": Context Available", reqID,
+					assert.JSONPathEqual(mission, "$.id", mission.ID),
+					fmt.Sprintf("Mission orchestration context is available - Mission ID: %s", mission.ID))
+			},
+		},
+		staticCase("mission-orchestration", testName+": Full Tests", reqID, NotImplementedTest(testName+": Full Tests", reqID,
+			"State transitions, checkpoints, memory continuity and constraint tests are not implemented")),
 	}
-
-	results = append(results, SkipTest(testName+": Full Tests", reqID,
-		"Complete mission orchestration tests require daemon integration"))
-
-	return results
 }
 
 // testWorkflowEngine tests workflow DAG execution (Req 19)
-func (m *ComprehensiveFrameworkModule) testWorkflowEngine(ctx context.Context, h agent.Harness) []runner.TestResult {
-	results := []runner.TestResult{}
+func (m *ComprehensiveFrameworkModule) testWorkflowEngine() []runner.TestCase {
 	testName := "Workflow Engine"
 	reqID := "19"
 
-	// Workflow tests would verify:
-	// - YAML parsing
+	// Mission YAML is validated by the workflow module; execution tests would verify:
 	// - Sequential/parallel execution
 	// - Conditional routing
 	// - Retry policies
 
-	results = append(results, SkipTest(testName, reqID,
-		"Workflow engine tests require daemon and test workflow YAML files"))
-
-	results = append(results, PassTest(testName+": Design Complete", reqID,
-		"Workflow engine test design is documented"))
-
-	return results
-}
-
-// testComponentRegistry tests etcd service discovery (Req 20)
-func (m *ComprehensiveFrameworkModule) testComponentRegistry(ctx context.Context, h agent.Harness) []runner.TestResult {
-	results := []runner.TestResult{}
-	testName := "Component Registry"
-	reqID := "20"
-
-	// Registry tests would verify:
-	// - Agent/tool/plugin registration
-	// - Service discovery
-	// - Health monitoring
-	// - Clean deregistration
-
-	results = append(results, SkipTest(testName, reqID,
-		"Component registry tests require embedded etcd access"))
-
-	return results
+	return []runner.TestCase{
+		staticCase("workflow-engine", testName, reqID, NotImplementedTest(testName, reqID,
+			"Workflow execution tests (parallel execution, routing, retries) are not implemented")),
+	}
 }
 
 // testDatabaseLayer tests SQLite operations (Req 21)
-func (m *ComprehensiveFrameworkModule) testDatabaseLayer(ctx context.Context, h agent.Harness) []runner.TestResult {
-	results := []runner.TestResult{}
+func (m *ComprehensiveFrameworkModule) testDatabaseLayer() []runner.TestCase {
 	testName := "Database Layer"
 	reqID := "21"
 
@@ -153,59 +134,55 @@ func (m *ComprehensiveFrameworkModule) testDatabaseLayer(ctx context.Context, h
 	// - Encrypted credential storage
 	// - Concurrent access
 
-	results = append(results, SkipTest(testName, reqID,
-		"Database layer tests require direct database access"))
-
-	results = append(results, PassTest(testName+": Design Complete", reqID,
-		"Database layer test design is documented"))
-
-	return results
+	return []runner.TestCase{
+		staticCase("database-layer", testName, reqID, NotImplementedTest(testName, reqID,
+			"Database layer tests (WAL mode, FTS5, credential storage) are not implemented")),
+	}
 }
 
 // testOtherComponents tests remaining framework components (Reqs 22-31)
-func (m *ComprehensiveFrameworkModule) testOtherComponents(ctx context.Context, h agent.Harness) []runner.TestResult {
-	results := []runner.TestResult{}
+func (m *ComprehensiveFrameworkModule) testOtherComponents() []runner.TestCase {
 	reqID := "22-31"
 
-	// Component lifecycle (Req 22)
-	results = append(results, SkipTest("Component Lifecycle", reqID,
-		"Component lifecycle tests require install/uninstall operations"))
-
-	// CLI commands (Req 23)
-	results = append(results, SkipTest("CLI Commands", reqID,
-		"CLI command tests require subprocess execution"))
+	return []runner.TestCase{
+		// Component lifecycle (Req 22)
+		staticCase("component-lifecycle", "Component Lifecycle", reqID, NotImplementedTest("Component Lifecycle", reqID,
+			"Component install/uninstall tests are not implemented")),
 
-	// TUI integration (Req 24)
-	results = append(results, SkipTest("TUI Integration", reqID,
-		"TUI integration tests require Subscribe RPC and event streaming"))
+		// CLI commands (Req 23)
+		staticCase("cli-commands", "CLI Commands", reqID, NotImplementedTest("CLI Commands", reqID,
+			"CLI command tests are not implemented")),
 
-	// LLM Provider Registry (Req 25)
-	results = append(results, PassTest("LLM Provider Registry", reqID,
-		"LLM provider registry is tested via SDK LLM integration tests"))
+		// TUI integration (Req 24)
+		staticCase("tui-integration", "TUI Integration", reqID, NotImplementedTest("TUI Integration", reqID,
+			"TUI event streaming tests are not implemented")),
 
-	// Framework Harness (Req 26)
-	results = append(results, PassTest("Framework Harness", reqID,
-		"Framework harness is being used for all these tests - validated implicitly"))
+		// LLM Provider Registry (Req 25)
+		staticCase("llm-provider-registry", "LLM Provider Registry", reqID, NotImplementedTest("LLM Provider Registry", reqID,
+			"No registry check; the llm module only calls the primary provider")),
 
-	// Prompt System (Req 27)
-	results = append(results, PassTest("Prompt System", reqID,
-		"Prompt system is tested via LLM Complete() operations"))
+		// Framework Harness (Req 26)
+		staticCase("framework-harness", "Framework Harness", reqID, NotImplementedTest("Framework Harness", reqID,
+			"No dedicated harness check; the harness is only exercised indirectly by other tests")),
 
-	// Observability Stack (Req 28)
-	results = append(results, PassTest("Observability Stack", reqID,
-		"Observability (logging/tracing) is tested via SDK observability tests"))
+		// Prompt System (Req 27)
+		staticCase("prompt-system", "Prompt System", reqID, NotImplementedTest("Prompt System", reqID,
+			"Prompt system tests are not implemented")),
 
-	// Configuration System (Req 29)
-	results = append(results, PassTest("Configuration System", reqID,
-		"Configuration system is tested via config parsing in debug agent itself"))
+		// Observability Stack (Req 28)
+		staticCase("observability-stack", "Observability Stack", reqID, NotImplementedTest("Observability Stack", reqID,
+			"Logging, tracing and metrics export tests are not implemented")),
 
-	// Neo4j Integration (Req 30)
-	results = append(results, SkipTest("Neo4j Integration", reqID,
-		"Neo4j integration tests require database connection"))
+		// Configuration System (Req 29)
+		staticCase("configuration-system", "Configuration System", reqID, NotImplementedTest("Configuration System", reqID,
+			"Framework configuration tests are not implemented; only the debug agent's own config is validated")),
 
-	// Finding Deduplication (Req 31)
-	results = append(results, SkipTest("Finding Deduplication", reqID,
-		"Deduplication tests require submitting multiple similar findings"))
+		// Neo4j Integration (Req 30)
+		staticCase("neo4j-integration", "Neo4j Integration", reqID, NotImplementedTest("Neo4j Integration", reqID,
+			"Neo4j integration tests are not implemented")),
 
-	return results
+		// Finding Deduplication (Req 31)
+		staticCase("finding-deduplication", "Finding Deduplication", reqID, NotImplementedTest("Finding Deduplication", reqID,
+			"Deduplication tests (submitting similar findings) are not implemented")),
+	}
 }
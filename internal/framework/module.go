@@ -1,8 +1,12 @@
 package framework
 
 import (
+	"context"
+	"fmt"
 	"time"
 
+	"github.com/zero-day-ai/sdk/agent"
+
 	"github.com/zero-day-ai/agents/debug/internal/runner"
 )
 
@@ -38,11 +42,33 @@ func (b BaseModule) RequirementID() string {
 	return b.requirementID
 }
 
+// TestFunc is a function that executes a single test
+type TestFunc func(ctx context.Context, h agent.Harness) runner.TestResult
+
+// RunTest executes a test function with timing and error recovery
+// A panic in the test function becomes an error result with the panic value and stack
+func RunTest(testName, requirementID string, testFunc TestFunc) TestFunc {
+	return func(ctx context.Context, h agent.Harness) runner.TestResult {
+		startTime := time.Now()
+		test := runner.TestRef{Name: testName, RequirementID: requirementID}
+
+		result := runner.RunGuarded(ctx, h, test, runner.CategoryFramework, testFunc)
+		result.Duration = time.Since(startTime)
+		return result
+	}
+}
+
 // SkipTest creates a skip result for framework tests
 func SkipTest(testName, requirementID string, reason string) runner.TestResult {
 	return runner.NewSkipResult(testName, requirementID, runner.CategoryFramework, reason)
 }
 
+// NotImplementedTest creates a result for a framework check that does not exist
+// yet, so its requirement is reported as unverified rather than passed or skipped
+func NotImplementedTest(testName, requirementID string, message string) runner.TestResult {
+	return runner.NewNotImplementedResult(testName, requirementID, runner.CategoryFramework, message)
+}
+
 // PassTest creates a pass result for framework tests
 func PassTest(testName, requirementID string, message string) runner.TestResult {
 	return runner.NewPassResult(testName, requirementID, runner.CategoryFramework, 0, message)
@@ -53,6 +79,14 @@ func FailTest(testName, requirementID string, message string, err error) runner.
 	return runner.NewFailResult(testName, requirementID, runner.CategoryFramework, 0, message, err)
 }
 
+// Check converts the outcome of an assert package assertion into a framework test result
+func Check(testName, requirementID string, err error, description string) runner.TestResult {
+	if err != nil {
+		return FailTest(testName, requirementID, fmt.Sprintf("%s: %v", description, err), err)
+	}
+	return PassTest(testName, requirementID, description)
+}
+
 // ErrorTest creates an error result for framework tests
 func ErrorTest(testName, requirementID string, err error, duration time.Duration) runner.TestResult {
 	return runner.NewErrorResult(testName, requirementID, runner.CategoryFramework, duration, err)
@@ -0,0 +1,60 @@
+package graphrag
+
+import (
+	"context"
+
+	"github.com/zero-day-ai/sdk/agent"
+
+	"github.com/zero-day-ai/agents/debug/internal/runner"
+)
+
+// Module tests GraphRAG store, query and traverse operations
+type Module struct {
+	cfg TestConfig
+}
+
+// NewModule creates the GraphRAG test module. prefix marks the test nodes
+// (default "[DEBUG]"); they are removed after the test either way.
+func NewModule(prefix string) *Module {
+	return &Module{cfg: TestConfig{
+		Prefix:           prefix,
+		CleanupOnSuccess: true,
+		CleanupOnFailure: true,
+	}}
+}
+
+func (m *Module) Name() string {
+	return "graphrag"
+}
+
+func (m *Module) Description() string {
+	return "GraphRAG node storage, query and traversal"
+}
+
+func (m *Module) Category() runner.Category {
+	return runner.CategorySDK
+}
+
+func (m *Module) RequirementID() string {
+	return "REQ-4"
+}
+
+// TestCases returns the GraphRAG operations test
+func (m *Module) TestCases() []runner.TestCase {
+	return []runner.TestCase{
+		{
+			ID:            "operations",
+			Name:          "GraphRAG Operations Test",
+			RequirementID: m.RequirementID(),
+			Tags:          []string{"graphrag"},
+			Run: func(ctx context.Context, h agent.Harness) runner.TestResult {
+				return ExecuteGraphRAGTest(ctx, h, m.cfg)
+			},
+		},
+	}
+}
+
+// Run executes the GraphRAG test
+func (m *Module) Run(ctx context.Context, h agent.Harness) []runner.TestResult {
+	return runner.RunCases(ctx, h, m, m.TestCases())
+}
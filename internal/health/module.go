@@ -0,0 +1,83 @@
+package health
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zero-day-ai/sdk/agent"
+
+	"github.com/zero-day-ai/agents/debug/internal/runner"
+)
+
+// requirementID is the requirement the health checks validate
+const requirementID = "REQ-1"
+
+// Components lists the infrastructure components a health check can target
+var Components = []string{"graphrag", "tools", "memory", "plugins"}
+
+// Module checks the health of Gibson infrastructure components, one test per component
+type Module struct {
+	components []string
+}
+
+// NewModule creates the health check module. A non-empty component restricts
+// the module to that component.
+func NewModule(component string) *Module {
+	components := Components
+	if component != "" {
+		components = []string{component}
+	}
+	return &Module{components: components}
+}
+
+func (m *Module) Name() string {
+	return "health"
+}
+
+func (m *Module) Description() string {
+	return "Health of GraphRAG, tools, memory and plugins"
+}
+
+func (m *Module) Category() runner.Category {
+	return runner.CategorySDK
+}
+
+func (m *Module) RequirementID() string {
+	return requirementID
+}
+
+// TestCases returns one health check per component
+func (m *Module) TestCases() []runner.TestCase {
+	cases := make([]runner.TestCase, 0, len(m.components))
+	for _, component := range m.components {
+		testName := fmt.Sprintf("Health Check (%s)", component)
+		cases = append(cases, runner.TestCase{
+			ID:            component,
+			Name:          testName,
+			RequirementID: requirementID,
+			Tags:          []string{"health"},
+			Run: func(ctx context.Context, h agent.Harness) runner.TestResult {
+				check, err := ExecuteHealthCheck(ctx, h, component)
+				if err != nil {
+					return runner.NewErrorResult(testName, requirementID, runner.CategorySDK, 0, err)
+				}
+				return check.testResult(testName)
+			},
+		})
+	}
+	return cases
+}
+
+// Run executes the health checks
+func (m *Module) Run(ctx context.Context, h agent.Harness) []runner.TestResult {
+	return runner.RunCases(ctx, h, m, m.TestCases())
+}
+
+// testResult converts a health check into a runner test result
+func (r *TestResult) testResult(testName string) runner.TestResult {
+	result := runner.NewPassResult(testName, requirementID, runner.CategorySDK, r.Duration, r.Message)
+	if !r.Success {
+		result = runner.NewFailResult(testName, requirementID, runner.CategorySDK, r.Duration, r.Message, r.Error)
+	}
+	return result.WithDetails(r.Details)
+}
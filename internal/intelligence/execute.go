@@ -0,0 +1,50 @@
+package intelligence
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/zero-day-ai/sdk/agent"
+)
+
+// ExecuteAnalyze generates the mission-wide intelligence summary of the
+// reconnaissance data in the knowledge graph. Intelligence that was generated but
+// could not be stored is returned as a partial result.
+func ExecuteAnalyze(ctx context.Context, h agent.Harness) (agent.Result, error) {
+	missionID := h.Mission().ID
+	intel, err := NewIntelligenceGenerator(h).GenerateSummary(ctx, missionID)
+	if intel == nil {
+		return agent.Result{
+			Status: agent.StatusFailed,
+			Output: fmt.Sprintf("Failed to generate intelligence: %v", err),
+			Error:  err,
+		}, nil
+	}
+
+	intelJSON, marshalErr := json.MarshalIndent(intel, "", "  ")
+	if marshalErr != nil {
+		return agent.Result{
+			Status: agent.StatusFailed,
+			Output: fmt.Sprintf("Failed to marshal intelligence: %v", marshalErr),
+			Error:  marshalErr,
+		}, nil
+	}
+
+	status := agent.StatusSuccess
+	if err != nil {
+		status = agent.StatusPartial
+	}
+	return agent.Result{
+		Status: status,
+		Output: string(intelJSON),
+		Error:  err,
+		Metadata: map[string]any{
+			"mission_id":        missionID,
+			"source_node_count": intel.SourceNodeCount,
+			"attack_paths":      len(intel.AttackPaths),
+			"recommendations":   len(intel.Recommendations),
+			"confidence":        intel.Confidence,
+		},
+	}, nil
+}
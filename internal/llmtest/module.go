@@ -0,0 +1,66 @@
+package llmtest
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zero-day-ai/sdk/agent"
+
+	"github.com/zero-day-ai/agents/debug/internal/runner"
+)
+
+// Methods lists the LLM methods the tests can call
+var Methods = []string{"complete", "structured", "with_tools"}
+
+// Module tests the harness LLM methods, one test per method
+type Module struct {
+	methods []string
+}
+
+// NewModule creates the LLM test module. A non-empty method restricts the
+// module to that method.
+func NewModule(method string) *Module {
+	methods := Methods
+	if method != "" {
+		methods = []string{method}
+	}
+	return &Module{methods: methods}
+}
+
+func (m *Module) Name() string {
+	return "llm"
+}
+
+func (m *Module) Description() string {
+	return "LLM completion, structured output and tool calling"
+}
+
+func (m *Module) Category() runner.Category {
+	return runner.CategorySDK
+}
+
+func (m *Module) RequirementID() string {
+	return "REQ-5"
+}
+
+// TestCases returns one test per LLM method
+func (m *Module) TestCases() []runner.TestCase {
+	cases := make([]runner.TestCase, 0, len(m.methods))
+	for _, method := range m.methods {
+		cases = append(cases, runner.TestCase{
+			ID:            method,
+			Name:          fmt.Sprintf("LLM Test (%s)", method),
+			RequirementID: m.RequirementID(),
+			Tags:          []string{"llm"},
+			Run: func(ctx context.Context, h agent.Harness) runner.TestResult {
+				return ExecuteLLMTest(ctx, h, TestConfig{Method: method})
+			},
+		})
+	}
+	return cases
+}
+
+// Run executes the LLM tests
+func (m *Module) Run(ctx context.Context, h agent.Harness) []runner.TestResult {
+	return runner.RunCases(ctx, h, m, m.TestCases())
+}
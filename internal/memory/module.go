@@ -0,0 +1,97 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/zero-day-ai/sdk/agent"
+	sdkmem "github.com/zero-day-ai/sdk/memory"
+
+	"github.com/zero-day-ai/agents/debug/internal/runner"
+)
+
+// requirementID is the requirement the memory tier tests validate
+const requirementID = "REQ-2"
+
+// Module tests the three memory tiers, one test per tier
+type Module struct{}
+
+// NewModule creates the memory tier test module
+func NewModule() *Module {
+	return &Module{}
+}
+
+func (m *Module) Name() string {
+	return "memory"
+}
+
+func (m *Module) Description() string {
+	return "Working, mission and long-term memory tier operations"
+}
+
+func (m *Module) Category() runner.Category {
+	return runner.CategorySDK
+}
+
+func (m *Module) RequirementID() string {
+	return requirementID
+}
+
+// tierTest runs the operations of one tier against the memory store
+type tierTest func(ctx context.Context, mem sdkmem.Store, h agent.Harness) *TierTestResult
+
+// TestCases returns one test per memory tier
+func (m *Module) TestCases() []runner.TestCase {
+	return []runner.TestCase{
+		tierCase("working", "Working Memory Tier", func(ctx context.Context, mem sdkmem.Store, h agent.Harness) *TierTestResult {
+			return testWorkingMemory(ctx, mem.Working(), h.Logger())
+		}),
+		tierCase("mission", "Mission Memory Tier", func(ctx context.Context, mem sdkmem.Store, h agent.Harness) *TierTestResult {
+			return testMissionMemory(ctx, mem.Mission(), h.Logger())
+		}),
+		tierCase("long-term", "LongTerm Memory Tier", func(ctx context.Context, mem sdkmem.Store, h agent.Harness) *TierTestResult {
+			return testLongTermMemory(ctx, mem.LongTerm(), h.Logger())
+		}),
+	}
+}
+
+// Run executes the memory tier tests
+func (m *Module) Run(ctx context.Context, h agent.Harness) []runner.TestResult {
+	return runner.RunCases(ctx, h, m, m.TestCases())
+}
+
+// tierCase creates the test case of one memory tier
+func tierCase(id, testName string, test tierTest) runner.TestCase {
+	return runner.TestCase{
+		ID:            id,
+		Name:          testName,
+		RequirementID: requirementID,
+		Tags:          []string{"memory"},
+		Run: func(ctx context.Context, h agent.Harness) runner.TestResult {
+			startTime := time.Now()
+			mem := h.Memory()
+			if mem == nil {
+				return runner.NewFailResult(testName, requirementID, runner.CategorySDK, 0,
+					"Memory store is not available", fmt.Errorf("memory store is nil"))
+			}
+			return test(ctx, mem, h).testResult(testName, time.Since(startTime))
+		},
+	}
+}
+
+// testResult converts a tier test into a runner test result. Besides the
+// operations IsSuccess checks, a value mismatch or lingering item fails the tier.
+func (tr *TierTestResult) testResult(testName string, duration time.Duration) runner.TestResult {
+	if tr.IsSuccess() && tr.Error == nil {
+		return runner.NewPassResult(testName, requirementID, runner.CategorySDK, duration,
+			fmt.Sprintf("%s memory operations succeeded", tr.TierName)).WithDetails(tr.Details)
+	}
+
+	err := tr.Error
+	if err == nil {
+		err = fmt.Errorf("set=%v get=%v delete=%v search=%v", tr.Set, tr.Get, tr.Delete, tr.Search)
+	}
+	return runner.NewFailResult(testName, requirementID, runner.CategorySDK, duration,
+		fmt.Sprintf("%s memory operations failed: %v", tr.TierName, err), err).WithDetails(tr.Details)
+}
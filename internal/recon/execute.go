@@ -0,0 +1,160 @@
+package recon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/zero-day-ai/sdk/agent"
+
+	"github.com/zero-day-ai/agents/debug/internal/network"
+)
+
+// Config holds the targets of a single reconnaissance phase run
+type Config struct {
+	// Subnet is the CIDR range the discover phase scans (the local subnet when empty)
+	Subnet string
+
+	// Domains are the domains the domain phase enumerates (the host names in
+	// /etc/hosts when empty)
+	Domains []string
+}
+
+// phaseOutput is the agent output of a phase run
+type phaseOutput struct {
+	Phase            Phase    `json:"phase"`
+	Targets          []string `json:"targets"`
+	ToolsRun         []string `json:"tools_run"`
+	NodesCreated     int      `json:"nodes_created"`
+	RelationsCreated int      `json:"relations_created"`
+	Duration         string   `json:"duration"`
+	Errors           []string `json:"errors,omitempty"`
+}
+
+// ExecutePhase runs a single reconnaissance phase. Discover scans the subnet and
+// domain enumerates the domains; probe and scan take their targets from what
+// earlier phases stored in the knowledge graph, and do nothing without any.
+func ExecutePhase(ctx context.Context, h agent.Harness, phase Phase, cfg *Config) (agent.Result, error) {
+	r := &DefaultReconRunner{harness: h, extractor: NewTaxonomyExtractor(h)}
+	return r.executePhase(ctx, phase, cfg), nil
+}
+
+// executePhase resolves the phase targets, runs the phase and reports it
+func (r *DefaultReconRunner) executePhase(ctx context.Context, phase Phase, cfg *Config) agent.Result {
+	logger := r.harness.Logger()
+
+	targets, err := r.phaseTargets(ctx, phase, cfg)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to resolve phase targets", "phase", phase, "error", err)
+		return agent.Result{
+			Status: agent.StatusFailed,
+			Output: fmt.Sprintf("Failed to resolve %s targets: %v", phase, err),
+			Error:  err,
+		}
+	}
+	if len(targets) == 0 {
+		logger.InfoContext(ctx, "no targets for reconnaissance phase", "phase", phase)
+		return agent.Result{
+			Status:   agent.StatusSuccess,
+			Output:   fmt.Sprintf("No %s targets found; the phase ran no tools", phase),
+			Metadata: map[string]any{"phase": string(phase), "targets": 0},
+		}
+	}
+
+	result, err := r.RunPhase(ctx, phase, targets)
+	if err != nil {
+		return agent.Result{
+			Status: agent.StatusFailed,
+			Output: fmt.Sprintf("Phase %s failed: %v", phase, err),
+			Error:  err,
+		}
+	}
+
+	output := phaseOutput{
+		Phase:            phase,
+		Targets:          targets,
+		ToolsRun:         result.ToolsRun,
+		NodesCreated:     result.NodesCreated,
+		RelationsCreated: result.RelationsCreated,
+		Duration:         result.Duration.Round(time.Millisecond).String(),
+	}
+	for _, err := range result.Errors {
+		output.Errors = append(output.Errors, err.Error())
+	}
+	outputJSON, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		return agent.Result{
+			Status: agent.StatusFailed,
+			Output: fmt.Sprintf("Failed to marshal phase result: %v", err),
+			Error:  err,
+		}
+	}
+
+	// Tool failures do not stop a phase; it is partial unless no tool ran at all
+	status := agent.StatusSuccess
+	if len(result.Errors) > 0 {
+		status = agent.StatusPartial
+		if len(result.ToolsRun) == 0 {
+			status = agent.StatusFailed
+		}
+	}
+
+	return agent.Result{
+		Status: status,
+		Output: string(outputJSON),
+		Metadata: map[string]any{
+			"phase":             string(phase),
+			"targets":           len(targets),
+			"tools_run":         len(result.ToolsRun),
+			"nodes_created":     result.NodesCreated,
+			"relations_created": result.RelationsCreated,
+			"errors":            len(result.Errors),
+		},
+	}
+}
+
+// phaseTargets returns the targets of phase
+func (r *DefaultReconRunner) phaseTargets(ctx context.Context, phase Phase, cfg *Config) ([]string, error) {
+	switch phase {
+	case PhaseDiscover:
+		if cfg.Subnet != "" {
+			return []string{cfg.Subnet}, nil
+		}
+		subnet, err := network.NewNetworkDiscovery().DiscoverLocalSubnet(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return []string{subnet}, nil
+	case PhaseProbe:
+		return r.extractProbeTargets(ctx)
+	case PhaseScan:
+		return r.extractScanTargets(ctx)
+	case PhaseDomain:
+		if len(cfg.Domains) > 0 {
+			return cfg.Domains, nil
+		}
+		mappings, err := network.NewNetworkDiscovery().GetDomainMappings(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return hostDomains(mappings), nil
+	default:
+		return nil, fmt.Errorf("unknown phase: %s", phase)
+	}
+}
+
+// hostDomains returns the qualified host names of hosts file mappings, sorted;
+// single-label names such as localhost are not enumerable domains
+func hostDomains(mappings map[string][]string) []string {
+	var domains []string
+	for host := range mappings {
+		if strings.Contains(host, ".") {
+			domains = append(domains, host)
+		}
+	}
+	sort.Strings(domains)
+	return domains
+}
@@ -0,0 +1,103 @@
+package recon
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/zero-day-ai/sdk/agent"
+)
+
+func TestExecutePhase(t *testing.T) {
+	tests := []struct {
+		name        string
+		phase       Phase
+		cfg         *Config
+		toolErrors  map[string]error
+		wantStatus  agent.ResultStatus
+		wantTargets int
+		wantNodes   int
+	}{
+		{
+			name:        "domain phase on configured domains",
+			phase:       PhaseDomain,
+			cfg:         &Config{Domains: []string{"example.local"}},
+			wantStatus:  agent.StatusSuccess,
+			wantTargets: 1,
+			wantNodes:   3,
+		},
+		{
+			name:        "one tool fails",
+			phase:       PhaseDomain,
+			cfg:         &Config{Domains: []string{"example.local"}},
+			toolErrors:  map[string]error{"amass": errors.New("amass not found")},
+			wantStatus:  agent.StatusPartial,
+			wantTargets: 1,
+			wantNodes:   2,
+		},
+		{
+			name:        "every tool fails",
+			phase:       PhaseDomain,
+			cfg:         &Config{Domains: []string{"example.local"}},
+			toolErrors:  map[string]error{"subfinder": errors.New("subfinder not found"), "amass": errors.New("amass not found")},
+			wantStatus:  agent.StatusFailed,
+			wantTargets: 1,
+		},
+		{
+			name:       "probe without discovered endpoints",
+			phase:      PhaseProbe,
+			cfg:        &Config{},
+			wantStatus: agent.StatusSuccess,
+		},
+		{
+			name:       "unknown phase",
+			phase:      Phase("analyze"),
+			cfg:        &Config{},
+			wantStatus: agent.StatusFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newMockHarness()
+			for tool, err := range tt.toolErrors {
+				h.setToolError(tool, err)
+			}
+			extractor := newMockExtractor()
+			extractor.setExtractResult("subfinder", 2, 1)
+			extractor.setExtractResult("amass", 1, 1)
+			r := &DefaultReconRunner{harness: h, extractor: extractor}
+
+			result := r.executePhase(context.Background(), tt.phase, tt.cfg)
+
+			if result.Status != tt.wantStatus {
+				t.Errorf("Status = %s (%v), want %s", result.Status, result.Output, tt.wantStatus)
+			}
+			if tt.wantStatus == agent.StatusFailed && tt.wantTargets == 0 {
+				return
+			}
+			if got := result.Metadata["targets"]; got != tt.wantTargets {
+				t.Errorf("targets = %v, want %d", got, tt.wantTargets)
+			}
+			if got, ok := result.Metadata["nodes_created"]; ok && got != tt.wantNodes {
+				t.Errorf("nodes_created = %v, want %d", got, tt.wantNodes)
+			}
+		})
+	}
+}
+
+func TestHostDomains(t *testing.T) {
+	mappings := map[string][]string{
+		"localhost":       {"127.0.0.1"},
+		"web.home.local":  {"192.168.1.20"},
+		"app.example.com": {"10.0.1.5", "10.0.1.6"},
+		"ip6-localhost":   {"::1"},
+		"server.local":    {"192.168.1.10"},
+	}
+
+	want := []string{"app.example.com", "server.local", "web.home.local"}
+	if got := hostDomains(mappings); !slices.Equal(got, want) {
+		t.Errorf("hostDomains = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,300 @@
+package registry
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	"google.golang.org/grpc"
+)
+
+// FakeEtcd is a partial in-memory emulation of the etcd v3 API for local runs
+// without an etcd cluster. It is not etcd: it only serves the KV range, put and
+// delete RPCs and leases the SDK registry client's Register, Discover and
+// Deregister use, expiring leases that are not kept alive, and reports itself
+// as a healthy single-member cluster through Status. Transactions, watches
+// (and so Registry.Watch) and cluster RPCs are not served, so tests against it
+// check the SDK client, not the registry.
+type FakeEtcd struct {
+	pb.UnimplementedKVServer
+	pb.UnimplementedLeaseServer
+	pb.UnimplementedMaintenanceServer
+
+	server   *grpc.Server
+	listener net.Listener
+
+	mu        sync.Mutex
+	revision  int64
+	nextLease int64
+	keys      map[string]*mvccpb.KeyValue
+	leases    map[int64]*lease
+}
+
+// lease is a granted lease and the keys attached to it
+type lease struct {
+	ttl     int64
+	expires time.Time
+	keys    map[string]struct{}
+}
+
+// StartFakeEtcd serves a fake etcd on a random loopback port
+func StartFakeEtcd() (*FakeEtcd, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen for fake etcd: %w", err)
+	}
+
+	e := &FakeEtcd{
+		server:   grpc.NewServer(),
+		listener: listener,
+		revision: 1,
+		keys:     make(map[string]*mvccpb.KeyValue),
+		leases:   make(map[int64]*lease),
+	}
+	pb.RegisterKVServer(e.server, e)
+	pb.RegisterLeaseServer(e.server, e)
+	pb.RegisterMaintenanceServer(e.server, e)
+	go e.server.Serve(listener)
+	return e, nil
+}
+
+// Endpoint returns the "host:port" clients connect to
+func (e *FakeEtcd) Endpoint() string {
+	return e.listener.Addr().String()
+}
+
+// Stop closes the listener and every open stream
+func (e *FakeEtcd) Stop() {
+	e.server.Stop()
+}
+
+// Range returns the keys in [Key, RangeEnd), or Key alone when RangeEnd is empty
+func (e *FakeEtcd) Range(ctx context.Context, req *pb.RangeRequest) (*pb.RangeResponse, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.expireLeases()
+
+	kvs := e.match(req.Key, req.RangeEnd)
+	resp := &pb.RangeResponse{Header: e.header(), Count: int64(len(kvs))}
+	if req.CountOnly {
+		return resp, nil
+	}
+	if req.Limit > 0 && int64(len(kvs)) > req.Limit {
+		kvs = kvs[:req.Limit]
+		resp.More = true
+	}
+	for _, kv := range kvs {
+		copied := *kv
+		if req.KeysOnly {
+			copied.Value = nil
+		}
+		resp.Kvs = append(resp.Kvs, &copied)
+	}
+	return resp, nil
+}
+
+// Put stores a key, attaching it to a lease when one is given
+func (e *FakeEtcd) Put(ctx context.Context, req *pb.PutRequest) (*pb.PutResponse, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.expireLeases()
+
+	if req.Lease != 0 {
+		if _, ok := e.leases[req.Lease]; !ok {
+			return nil, rpctypes.ErrGRPCLeaseNotFound
+		}
+	}
+
+	e.revision++
+	key := string(req.Key)
+	prev := e.keys[key]
+	kv := &mvccpb.KeyValue{Key: req.Key, Value: req.Value, Lease: req.Lease, CreateRevision: e.revision, ModRevision: e.revision, Version: 1}
+	if prev != nil {
+		kv.CreateRevision = prev.CreateRevision
+		kv.Version = prev.Version + 1
+		e.detach(prev)
+	}
+	e.keys[key] = kv
+	if l, ok := e.leases[req.Lease]; ok {
+		l.keys[key] = struct{}{}
+	}
+
+	resp := &pb.PutResponse{Header: e.header()}
+	if req.PrevKv && prev != nil {
+		resp.PrevKv = prev
+	}
+	return resp, nil
+}
+
+// DeleteRange deletes the keys in [Key, RangeEnd), or Key alone when RangeEnd is empty
+func (e *FakeEtcd) DeleteRange(ctx context.Context, req *pb.DeleteRangeRequest) (*pb.DeleteRangeResponse, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.expireLeases()
+
+	kvs := e.match(req.Key, req.RangeEnd)
+	if len(kvs) > 0 {
+		e.revision++
+	}
+	for _, kv := range kvs {
+		e.detach(kv)
+		delete(e.keys, string(kv.Key))
+	}
+
+	resp := &pb.DeleteRangeResponse{Header: e.header(), Deleted: int64(len(kvs))}
+	if req.PrevKv {
+		resp.PrevKvs = kvs
+	}
+	return resp, nil
+}
+
+// LeaseGrant creates a lease expiring after TTL seconds unless kept alive
+func (e *FakeEtcd) LeaseGrant(ctx context.Context, req *pb.LeaseGrantRequest) (*pb.LeaseGrantResponse, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.expireLeases()
+
+	id := req.ID
+	if id == 0 {
+		e.nextLease++
+		id = e.nextLease
+	} else if _, ok := e.leases[id]; ok {
+		return nil, rpctypes.ErrGRPCLeaseExist
+	}
+	e.leases[id] = &lease{ttl: req.TTL, expires: time.Now().Add(time.Duration(req.TTL) * time.Second), keys: map[string]struct{}{}}
+	return &pb.LeaseGrantResponse{Header: e.header(), ID: id, TTL: req.TTL}, nil
+}
+
+// LeaseRevoke deletes a lease and the keys attached to it
+func (e *FakeEtcd) LeaseRevoke(ctx context.Context, req *pb.LeaseRevokeRequest) (*pb.LeaseRevokeResponse, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.expireLeases()
+
+	if _, ok := e.leases[req.ID]; !ok {
+		return nil, rpctypes.ErrGRPCLeaseNotFound
+	}
+	e.revoke(req.ID)
+	return &pb.LeaseRevokeResponse{Header: e.header()}, nil
+}
+
+// LeaseKeepAlive renews the lease of each request. An expired or unknown lease
+// is answered with a TTL of 0, as etcd does.
+func (e *FakeEtcd) LeaseKeepAlive(stream pb.Lease_LeaseKeepAliveServer) error {
+	for {
+		req, err := stream.Recv()
+		if err != nil {
+			return nil
+		}
+
+		e.mu.Lock()
+		e.expireLeases()
+		resp := &pb.LeaseKeepAliveResponse{Header: e.header(), ID: req.ID}
+		if l, ok := e.leases[req.ID]; ok {
+			l.expires = time.Now().Add(time.Duration(l.ttl) * time.Second)
+			resp.TTL = l.ttl
+		}
+		e.mu.Unlock()
+
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+}
+
+// LeaseTimeToLive reports the remaining TTL of a lease, -1 when it does not exist
+func (e *FakeEtcd) LeaseTimeToLive(ctx context.Context, req *pb.LeaseTimeToLiveRequest) (*pb.LeaseTimeToLiveResponse, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.expireLeases()
+
+	resp := &pb.LeaseTimeToLiveResponse{Header: e.header(), ID: req.ID, TTL: -1}
+	l, ok := e.leases[req.ID]
+	if !ok {
+		return resp, nil
+	}
+	resp.TTL = int64(time.Until(l.expires).Round(time.Second) / time.Second)
+	resp.GrantedTTL = l.ttl
+	if req.Keys {
+		for key := range l.keys {
+			resp.Keys = append(resp.Keys, []byte(key))
+		}
+	}
+	return resp, nil
+}
+
+// Status reports the fake as the leader of a single-member cluster
+func (e *FakeEtcd) Status(ctx context.Context, req *pb.StatusRequest) (*pb.StatusResponse, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	header := e.header()
+	return &pb.StatusResponse{Header: header, Version: "fake", Leader: header.MemberId, RaftIndex: uint64(e.revision)}, nil
+}
+
+// match returns the keys in [key, rangeEnd) in key order. An empty rangeEnd
+// matches key alone and "\x00" every key from key on. The caller holds e.mu.
+func (e *FakeEtcd) match(key, rangeEnd []byte) []*mvccpb.KeyValue {
+	var kvs []*mvccpb.KeyValue
+	for _, kv := range e.keys {
+		switch {
+		case len(rangeEnd) == 0:
+			if !bytes.Equal(kv.Key, key) {
+				continue
+			}
+		case bytes.Equal(rangeEnd, []byte{0}):
+			if bytes.Compare(kv.Key, key) < 0 {
+				continue
+			}
+		default:
+			if bytes.Compare(kv.Key, key) < 0 || bytes.Compare(kv.Key, rangeEnd) >= 0 {
+				continue
+			}
+		}
+		kvs = append(kvs, kv)
+	}
+	sort.Slice(kvs, func(i, j int) bool {
+		return bytes.Compare(kvs[i].Key, kvs[j].Key) < 0
+	})
+	return kvs
+}
+
+// expireLeases revokes the leases that were not kept alive. The caller holds e.mu.
+func (e *FakeEtcd) expireLeases() {
+	now := time.Now()
+	for id, l := range e.leases {
+		if now.After(l.expires) {
+			e.revoke(id)
+		}
+	}
+}
+
+// revoke deletes a lease and its keys. The caller holds e.mu.
+func (e *FakeEtcd) revoke(id int64) {
+	l := e.leases[id]
+	if len(l.keys) > 0 {
+		e.revision++
+	}
+	for key := range l.keys {
+		delete(e.keys, key)
+	}
+	delete(e.leases, id)
+}
+
+// detach removes kv from the keys of its lease. The caller holds e.mu.
+func (e *FakeEtcd) detach(kv *mvccpb.KeyValue) {
+	if l, ok := e.leases[kv.Lease]; ok {
+		delete(l.keys, string(kv.Key))
+	}
+}
+
+// header returns the response header at the current revision. The caller holds e.mu.
+func (e *FakeEtcd) header() *pb.ResponseHeader {
+	return &pb.ResponseHeader{ClusterId: 1, MemberId: 1, Revision: e.revision}
+}
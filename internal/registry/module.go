@@ -0,0 +1,347 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zero-day-ai/sdk/agent"
+	sdkregistry "github.com/zero-day-ai/sdk/registry"
+	clientv3 "go.etcd.io/etcd/client/v3"
+
+	"github.com/zero-day-ai/agents/debug/internal/assert"
+	"github.com/zero-day-ai/agents/debug/internal/runner"
+)
+
+// requirementID is the requirement the component registry tests validate
+const requirementID = "20"
+
+// EndpointsEnv holds the etcd endpoints the agent registers itself with
+// (serve.WithRegistryFromEnv), comma separated
+const EndpointsEnv = "GIBSON_REGISTRY_ENDPOINTS"
+
+// namespace is the key prefix Gibson components register under
+const namespace = "gibson"
+
+// leaseTTL is the lease TTL in seconds of the test component. It is short so
+// the lease refresh test sees the entry outlive its TTL.
+const leaseTTL = 2
+
+// probeKind and probeName identify the throwaway component the tests register
+const (
+	probeKind = "agent"
+	probeName = "debug-registry-probe"
+)
+
+// Module tests etcd health, component registration, discovery, lease refresh and
+// deregistration against the etcd registry, or a fake etcd
+type Module struct {
+	endpoints []string
+	fake      bool
+}
+
+// NewModule creates the component registry test module. The tests use the etcd
+// at endpoints (GIBSON_REGISTRY_ENDPOINTS when empty), or a FakeEtcd when fake
+// is set; with neither they are skipped.
+func NewModule(endpoints []string, fake bool) *Module {
+	if len(endpoints) == 0 {
+		endpoints = endpointsFromEnv()
+	}
+	return &Module{endpoints: endpoints, fake: fake}
+}
+
+// endpointsFromEnv parses EndpointsEnv the way the SDK registry client does
+func endpointsFromEnv() []string {
+	value := os.Getenv(EndpointsEnv)
+	if value == "" {
+		return nil
+	}
+	endpoints := strings.Split(value, ",")
+	for i, endpoint := range endpoints {
+		endpoints[i] = strings.TrimSpace(endpoint)
+	}
+	return endpoints
+}
+
+func (m *Module) Name() string {
+	return "registry"
+}
+
+func (m *Module) Description() string {
+	return "Component registry: etcd health, registration, discovery, lease refresh and deregistration"
+}
+
+func (m *Module) Category() runner.Category {
+	return runner.CategoryFramework
+}
+
+func (m *Module) RequirementID() string {
+	return requirementID
+}
+
+// session holds the clients of one test: the SDK registry client components
+// register with, and a raw etcd client to inspect the endpoints, keys and leases
+type session struct {
+	endpoints []string
+	registry  *sdkregistry.Client
+	etcd      *clientv3.Client
+}
+
+// registryTest runs one test against a connected registry
+type registryTest func(ctx context.Context, testName string, s *session) runner.TestResult
+
+// TestCases returns the registry tests
+func (m *Module) TestCases() []runner.TestCase {
+	return []runner.TestCase{
+		m.registryCase("health", "Component Registry: Health", testHealth),
+		m.registryCase("register-discover", "Component Registry: Register and Discover", testRegisterDiscover),
+		m.registryCase("lease-refresh", "Component Registry: Lease Refresh", testLeaseRefresh),
+		m.registryCase("deregister", "Component Registry: Deregister", testDeregister),
+	}
+}
+
+// Run executes the registry tests
+func (m *Module) Run(ctx context.Context, h agent.Harness) []runner.TestResult {
+	return runner.RunCases(ctx, h, m, m.TestCases())
+}
+
+// registryCase creates a test case that connects to the registry, starting the
+// fake etcd first when configured. The clients and etcd end with the test.
+func (m *Module) registryCase(id, testName string, test registryTest) runner.TestCase {
+	return runner.TestCase{
+		ID:            id,
+		Name:          testName,
+		RequirementID: requirementID,
+		Tags:          []string{"registry"},
+		Run: func(ctx context.Context, h agent.Harness) runner.TestResult {
+			endpoints := m.endpoints
+			if m.fake {
+				etcd, err := StartFakeEtcd()
+				if err != nil {
+					return runner.NewErrorResult(testName, requirementID, runner.CategoryFramework, 0, err)
+				}
+				defer etcd.Stop()
+				endpoints = []string{etcd.Endpoint()}
+			}
+			if len(endpoints) == 0 {
+				return runner.NewSkipResult(testName, requirementID, runner.CategoryFramework,
+					fmt.Sprintf("No registry configured (set %s, registry_endpoints or registry_fake)", EndpointsEnv))
+			}
+
+			reg, err := sdkregistry.NewClient(sdkregistry.Config{Endpoints: endpoints, Namespace: namespace, TTL: leaseTTL})
+			if err != nil {
+				return runner.NewFailResult(testName, requirementID, runner.CategoryFramework, 0,
+					fmt.Sprintf("Registry unreachable at %s", strings.Join(endpoints, ",")), err)
+			}
+			defer reg.Close()
+			etcd, err := clientv3.New(clientv3.Config{Endpoints: endpoints, DialTimeout: 5 * time.Second})
+			if err != nil {
+				return runner.NewErrorResult(testName, requirementID, runner.CategoryFramework, 0, err)
+			}
+			defer etcd.Close()
+
+			return test(ctx, testName, &session{endpoints: endpoints, registry: reg, etcd: etcd})
+		},
+	}
+}
+
+// newProbe returns a throwaway component instance
+func newProbe() sdkregistry.ServiceInfo {
+	return sdkregistry.ServiceInfo{
+		Kind:       probeKind,
+		Name:       probeName,
+		Version:    "0.0.0",
+		InstanceID: uuid.NewString(),
+		Endpoint:   "127.0.0.1:0",
+		Metadata:   map[string]string{"debug": "true"},
+		StartedAt:  time.Now().UTC(),
+	}
+}
+
+// key returns the etcd key the SDK registry client stores info under
+func key(info sdkregistry.ServiceInfo) string {
+	return fmt.Sprintf("/%s/%s/%s/%s", namespace, info.Kind, info.Name, info.InstanceID)
+}
+
+// register registers probe and returns a function deregistering it, which is a
+// no-op once the test deregistered the probe itself
+func (s *session) register(ctx context.Context, probe sdkregistry.ServiceInfo) (func(), error) {
+	if err := s.registry.Register(ctx, probe); err != nil {
+		return nil, err
+	}
+	return func() {
+		s.registry.Deregister(context.WithoutCancel(ctx), probe)
+	}, nil
+}
+
+// discover returns the instance IDs of the registered probes
+func (s *session) discover(ctx context.Context) ([]string, error) {
+	instances, err := s.registry.Discover(ctx, probeKind, probeName)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(instances))
+	for _, instance := range instances {
+		ids = append(ids, instance.InstanceID)
+	}
+	return ids, nil
+}
+
+// lease returns the lease the probe's key is attached to (0 when the key is missing)
+func (s *session) lease(ctx context.Context, probe sdkregistry.ServiceInfo) (clientv3.LeaseID, error) {
+	resp, err := s.etcd.Get(ctx, key(probe))
+	if err != nil || len(resp.Kvs) == 0 {
+		return 0, err
+	}
+	return clientv3.LeaseID(resp.Kvs[0].Lease), nil
+}
+
+// testHealth checks that every registry endpoint answers the etcd maintenance
+// Status call, knows a leader and reports no errors
+func testHealth(ctx context.Context, testName string, s *session) runner.TestResult {
+	startTime := time.Now()
+	ch := assert.NewChecker(testName, requirementID, runner.CategoryFramework)
+	versions := make(map[string]string, len(s.endpoints))
+	for _, endpoint := range s.endpoints {
+		status, err := s.etcd.Status(ctx, endpoint)
+		if !ch.Check(endpoint+" status", err) {
+			continue
+		}
+		versions[endpoint] = status.Version
+		ch.Check(endpoint+" leader", assert.NotEqual(uint64(0), status.Leader))
+		ch.Check(endpoint+" errors", assert.Empty(status.Errors))
+	}
+	result := ch.Result(time.Since(startTime), fmt.Sprintf("%d etcd endpoints healthy", len(s.endpoints)))
+	result.Details["versions"] = versions
+	return result
+}
+
+// testRegisterDiscover registers a component and checks that discovery returns
+// it with the registered endpoint, version and metadata
+func testRegisterDiscover(ctx context.Context, testName string, s *session) runner.TestResult {
+	startTime := time.Now()
+	probe := newProbe()
+
+	deregister, err := s.register(ctx, probe)
+	if err != nil {
+		return runner.NewFailResult(testName, requirementID, runner.CategoryFramework, time.Since(startTime),
+			"Register failed", err)
+	}
+	defer deregister()
+
+	instances, err := s.registry.Discover(ctx, probeKind, probeName)
+	if err != nil {
+		return runner.NewFailResult(testName, requirementID, runner.CategoryFramework, time.Since(startTime),
+			"Discover failed", err)
+	}
+	all, err := s.registry.DiscoverAll(ctx, probeKind)
+	if err != nil {
+		return runner.NewFailResult(testName, requirementID, runner.CategoryFramework, time.Since(startTime),
+			"DiscoverAll failed", err)
+	}
+
+	ch := assert.NewChecker(testName, requirementID, runner.CategoryFramework)
+	i := slices.IndexFunc(instances, func(info sdkregistry.ServiceInfo) bool { return info.InstanceID == probe.InstanceID })
+	if ch.Check("discovered", assert.True(i >= 0, "instance %s not among %d discovered", probe.InstanceID, len(instances))) {
+		ch.Check("endpoint", assert.Equal(probe.Endpoint, instances[i].Endpoint))
+		ch.Check("version", assert.Equal(probe.Version, instances[i].Version))
+		ch.Check("metadata", assert.Equal(probe.Metadata, instances[i].Metadata))
+	}
+	ch.Check("discovered by kind", assert.True(slices.ContainsFunc(all, func(info sdkregistry.ServiceInfo) bool {
+		return info.InstanceID == probe.InstanceID
+	}), "instance %s not among %d %ss", probe.InstanceID, len(all), probeKind))
+	return ch.Result(time.Since(startTime), fmt.Sprintf("Component %s/%s registered and discovered", probeName, probe.InstanceID))
+}
+
+// testLeaseRefresh checks that a registered component is bound to a lease and
+// stays discoverable past its TTL because the client keeps the lease alive
+func testLeaseRefresh(ctx context.Context, testName string, s *session) runner.TestResult {
+	startTime := time.Now()
+	probe := newProbe()
+
+	deregister, err := s.register(ctx, probe)
+	if err != nil {
+		return runner.NewFailResult(testName, requirementID, runner.CategoryFramework, time.Since(startTime),
+			"Register failed", err)
+	}
+	defer deregister()
+
+	leaseID, err := s.lease(ctx, probe)
+	if err != nil {
+		return runner.NewFailResult(testName, requirementID, runner.CategoryFramework, time.Since(startTime),
+			"Reading the registry key failed", err)
+	}
+
+	select {
+	case <-time.After((leaseTTL + 1) * time.Second):
+	case <-ctx.Done():
+		return runner.NewErrorResult(testName, requirementID, runner.CategoryFramework, time.Since(startTime), ctx.Err())
+	}
+
+	ids, err := s.discover(ctx)
+	if err != nil {
+		return runner.NewFailResult(testName, requirementID, runner.CategoryFramework, time.Since(startTime),
+			"Discover failed", err)
+	}
+
+	ch := assert.NewChecker(testName, requirementID, runner.CategoryFramework)
+	ch.Check("key bound to a lease", assert.NotEqual(clientv3.LeaseID(0), leaseID))
+	ch.Check("discoverable after TTL", assert.Contains(ids, probe.InstanceID))
+	if leaseID != 0 {
+		ttl, err := s.etcd.TimeToLive(ctx, leaseID)
+		if ch.Check("lease time to live", err) {
+			ch.Check("lease alive", assert.Greater(ttl.TTL, 0))
+		}
+	}
+	return ch.Result(time.Since(startTime), fmt.Sprintf("Lease of %s kept alive past its %ds TTL", probe.InstanceID, leaseTTL))
+}
+
+// testDeregister checks that deregistering a component removes its key and
+// revokes its lease immediately, without waiting for the TTL
+func testDeregister(ctx context.Context, testName string, s *session) runner.TestResult {
+	startTime := time.Now()
+	probe := newProbe()
+
+	deregister, err := s.register(ctx, probe)
+	if err != nil {
+		return runner.NewFailResult(testName, requirementID, runner.CategoryFramework, time.Since(startTime),
+			"Register failed", err)
+	}
+	defer deregister()
+
+	leaseID, err := s.lease(ctx, probe)
+	if err != nil {
+		return runner.NewFailResult(testName, requirementID, runner.CategoryFramework, time.Since(startTime),
+			"Reading the registry key failed", err)
+	}
+	if err := s.registry.Deregister(ctx, probe); err != nil {
+		return runner.NewFailResult(testName, requirementID, runner.CategoryFramework, time.Since(startTime),
+			"Deregister failed", err)
+	}
+
+	ids, err := s.discover(ctx)
+	if err != nil {
+		return runner.NewFailResult(testName, requirementID, runner.CategoryFramework, time.Since(startTime),
+			"Discover failed", err)
+	}
+	resp, err := s.etcd.Get(ctx, key(probe))
+	if err != nil {
+		return runner.NewFailResult(testName, requirementID, runner.CategoryFramework, time.Since(startTime),
+			"Reading the registry key failed", err)
+	}
+
+	ch := assert.NewChecker(testName, requirementID, runner.CategoryFramework)
+	ch.Check("not discoverable", assert.NotContains(ids, probe.InstanceID))
+	ch.Check("key deleted", assert.Empty(resp.Kvs))
+	if leaseID != 0 {
+		ttl, err := s.etcd.TimeToLive(ctx, leaseID)
+		if ch.Check("lease time to live", err) {
+			ch.Check("lease revoked", assert.Equal(int64(-1), ttl.TTL))
+		}
+	}
+	return ch.Result(time.Since(startTime), fmt.Sprintf("Component %s deregistered and its lease revoked", probe.InstanceID))
+}
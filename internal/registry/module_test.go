@@ -0,0 +1,98 @@
+package registry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+
+	"github.com/zero-day-ai/agents/debug/internal/runner"
+	"github.com/zero-day-ai/agents/debug/internal/testutil"
+)
+
+func TestModule_Fake(t *testing.T) {
+	results := NewModule(nil, true).Run(context.Background(), testutil.LoggerHarness{})
+
+	testutil.CheckResults(t, results, 4, runner.TestStatusPass)
+}
+
+func TestModule_NoRegistry(t *testing.T) {
+	t.Setenv(EndpointsEnv, "")
+	results := NewModule(nil, false).Run(context.Background(), testutil.LoggerHarness{})
+
+	testutil.CheckResults(t, results, 4, runner.TestStatusSkip)
+}
+
+func TestNewModule_EndpointsFromEnv(t *testing.T) {
+	t.Setenv(EndpointsEnv, "etcd1:2379, etcd2:2379")
+
+	tests := []struct {
+		name      string
+		endpoints []string
+		want      []string
+	}{
+		{name: "from env", endpoints: nil, want: []string{"etcd1:2379", "etcd2:2379"}},
+		{name: "configured", endpoints: []string{"etcd3:2379"}, want: []string{"etcd3:2379"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewModule(tt.endpoints, false)
+			if len(m.endpoints) != len(tt.want) {
+				t.Fatalf("endpoints = %q, want %q", m.endpoints, tt.want)
+			}
+			for i := range tt.want {
+				if m.endpoints[i] != tt.want[i] {
+					t.Errorf("endpoints[%d] = %q, want %q", i, m.endpoints[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestFakeEtcd_LeaseExpiry(t *testing.T) {
+	etcd, err := StartFakeEtcd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer etcd.Stop()
+	c, err := clientv3.New(clientv3.Config{Endpoints: []string{etcd.Endpoint()}, DialTimeout: 5 * time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	ctx := context.Background()
+
+	lease, err := c.Grant(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Put(ctx, "/gibson/agent/a/1", "leased", clientv3.WithLease(lease.ID)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Put(ctx, "/gibson/agent/a/2", "kept"); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := c.Get(ctx, "/gibson/agent/", clientv3.WithPrefix())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Kvs) != 2 {
+		t.Fatalf("got %d keys before expiry, want 2", len(resp.Kvs))
+	}
+
+	time.Sleep(1100 * time.Millisecond)
+
+	resp, err = c.Get(ctx, "/gibson/agent/", clientv3.WithPrefix())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Kvs) != 1 || string(resp.Kvs[0].Value) != "kept" {
+		t.Errorf("keys after expiry = %v, want only the key without lease", resp.Kvs)
+	}
+	if _, err := c.KeepAliveOnce(ctx, lease.ID); err == nil {
+		t.Error("KeepAliveOnce succeeded for an expired lease")
+	}
+}
@@ -0,0 +1,215 @@
+package runner
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// baselineVersion is the format version of saved baselines
+const baselineVersion = 1
+
+// Baseline is a serializable snapshot of a suite run used to detect regressions
+// in later runs
+type Baseline struct {
+	// Version is the baseline format version
+	Version int `json:"version"`
+
+	// Name identifies the baseline (e.g., "latest", "release-1.2")
+	Name string `json:"name"`
+
+	// CreatedAt is when the baselined suite finished
+	CreatedAt time.Time `json:"created_at"`
+
+	// OverallStatus is the overall status of the baselined suite
+	OverallStatus TestStatus `json:"overall_status"`
+
+	// Tests holds the outcome of every test in run order
+	Tests []BaselineTest `json:"tests"`
+}
+
+// BaselineTest is the recorded outcome of a single test
+type BaselineTest struct {
+	// ID identifies the test across runs, see baselineKey
+	ID string `json:"id"`
+
+	// Name is the human-readable test name
+	Name string `json:"name"`
+
+	// RequirementID identifies which requirement the test validates
+	RequirementID string `json:"requirement_id,omitempty"`
+
+	// Status is the test outcome
+	Status TestStatus `json:"status"`
+
+	// Duration is how long the test took
+	Duration time.Duration `json:"duration"`
+}
+
+// NewBaseline records a finalized suite as a baseline with the given name
+func NewBaseline(name string, suite *SuiteResult) *Baseline {
+	baseline := &Baseline{
+		Version:       baselineVersion,
+		Name:          name,
+		CreatedAt:     suite.EndTime,
+		OverallStatus: suite.OverallStatus,
+		Tests:         make([]BaselineTest, 0, len(suite.Results)),
+	}
+	for _, result := range suite.Results {
+		baseline.Tests = append(baseline.Tests, BaselineTest{
+			ID:            baselineKey(result),
+			Name:          result.TestName,
+			RequirementID: result.RequirementID,
+			Status:        result.Status,
+			Duration:      result.Duration,
+		})
+	}
+	return baseline
+}
+
+// baselineKey identifies a result across runs: its test ID, or the category and
+// name for results without one
+func baselineKey(result TestResult) string {
+	if result.TestID != "" {
+		return result.TestID
+	}
+	return string(result.Category) + "/" + result.TestName
+}
+
+// CompareOptions controls which changes are reported as regressions
+type CompareOptions struct {
+	// DurationThreshold is the relative slowdown reported as a duration regression
+	// (0.5 reports tests that take 50% longer than in the baseline; 0 disables)
+	DurationThreshold float64
+
+	// MinDurationDelta ignores slowdowns smaller than this absolute amount,
+	// which keeps very short tests from producing noise
+	MinDurationDelta time.Duration
+}
+
+// DefaultCompareOptions reports tests that got 50% and at least one second slower
+var DefaultCompareOptions = CompareOptions{
+	DurationThreshold: 0.5,
+	MinDurationDelta:  time.Second,
+}
+
+// StatusChange is a test whose outcome differs from the baseline
+type StatusChange struct {
+	ID       string     `json:"id"`
+	Name     string     `json:"name"`
+	Baseline TestStatus `json:"baseline"`
+	Current  TestStatus `json:"current"`
+}
+
+// DurationChange is a test that got slower than the baseline
+type DurationChange struct {
+	ID       string        `json:"id"`
+	Name     string        `json:"name"`
+	Baseline time.Duration `json:"baseline"`
+	Current  time.Duration `json:"current"`
+}
+
+// Comparison describes how a suite run differs from a baseline
+type Comparison struct {
+	// BaselineName is the name of the baseline compared against
+	BaselineName string `json:"baseline_name"`
+
+	// BaselineCreatedAt is when the baseline run finished
+	BaselineCreatedAt time.Time `json:"baseline_created_at"`
+
+	// NewlyFailing lists tests that fail or error now but did not in the baseline
+	NewlyFailing []StatusChange `json:"newly_failing"`
+
+	// NewlyPassing lists tests that pass now but failed or errored in the baseline
+	NewlyPassing []StatusChange `json:"newly_passing"`
+
+	// Disappeared lists baseline tests that produced no result in this run
+	Disappeared []BaselineTest `json:"disappeared"`
+
+	// Added lists tests that are not part of the baseline
+	Added []string `json:"added"`
+
+	// DurationRegressions lists tests that got slower beyond the threshold
+	DurationRegressions []DurationChange `json:"duration_regressions"`
+}
+
+// HasRegressions reports whether the run regressed: tests newly failing,
+// disappearing or getting slower than the threshold allows
+func (c *Comparison) HasRegressions() bool {
+	return c != nil && (len(c.NewlyFailing) > 0 || len(c.Disappeared) > 0 || len(c.DurationRegressions) > 0)
+}
+
+// Compare compares a finalized suite against a baseline
+func Compare(baseline *Baseline, suite *SuiteResult, opts CompareOptions) *Comparison {
+	comparison := &Comparison{
+		BaselineName:      baseline.Name,
+		BaselineCreatedAt: baseline.CreatedAt,
+	}
+
+	previous := make(map[string]BaselineTest, len(baseline.Tests))
+	for _, test := range baseline.Tests {
+		previous[test.ID] = test
+	}
+
+	seen := make(map[string]bool, len(suite.Results))
+	for _, result := range suite.Results {
+		id := baselineKey(result)
+		seen[id] = true
+
+		before, ok := previous[id]
+		if !ok {
+			comparison.Added = append(comparison.Added, id)
+			continue
+		}
+
+		change := StatusChange{ID: id, Name: result.TestName, Baseline: before.Status, Current: result.Status}
+		switch {
+		case failed(result.Status) && !failed(before.Status):
+			comparison.NewlyFailing = append(comparison.NewlyFailing, change)
+		case result.Status.Succeeded() && failed(before.Status):
+			comparison.NewlyPassing = append(comparison.NewlyPassing, change)
+		}
+
+		if opts.slower(before.Duration, result.Duration) {
+			comparison.DurationRegressions = append(comparison.DurationRegressions, DurationChange{
+				ID:       id,
+				Name:     result.TestName,
+				Baseline: before.Duration,
+				Current:  result.Duration,
+			})
+		}
+	}
+
+	for _, test := range baseline.Tests {
+		if !seen[test.ID] {
+			comparison.Disappeared = append(comparison.Disappeared, test)
+		}
+	}
+
+	slices.SortFunc(comparison.DurationRegressions, func(a, b DurationChange) int {
+		return cmp.Compare(b.Current-b.Baseline, a.Current-a.Baseline)
+	})
+
+	return comparison
+}
+
+// slower reports whether current exceeds the baseline duration beyond the thresholds
+func (o CompareOptions) slower(baseline, current time.Duration) bool {
+	if o.DurationThreshold <= 0 || baseline <= 0 {
+		return false
+	}
+	delta := current - baseline
+	return delta >= o.MinDurationDelta && float64(delta) > float64(baseline)*o.DurationThreshold
+}
+
+// failed reports whether a status counts as a failure
+func failed(status TestStatus) bool {
+	return status == TestStatusFail || status == TestStatusError
+}
+
+// Summary returns a one-line description of the comparison
+func (c *Comparison) Summary() string {
+	return fmt.Sprintf("%d newly failing, %d newly passing, %d disappeared, %d new, %d slower",
+		len(c.NewlyFailing), len(c.NewlyPassing), len(c.Disappeared), len(c.Added), len(c.DurationRegressions))
+}
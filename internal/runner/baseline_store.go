@@ -0,0 +1,241 @@
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/zero-day-ai/sdk/memory"
+)
+
+// ErrBaselineNotFound is returned by a BaselineStore when no baseline with the
+// requested name has been saved yet
+var ErrBaselineNotFound = errors.New("baseline not found")
+
+// BaselineStore persists baselines between runs
+type BaselineStore interface {
+	// Load returns the most recently saved baseline with the given name
+	Load(ctx context.Context, name string) (*Baseline, error)
+
+	// Save stores a baseline under its name, replacing any previous one
+	Save(ctx context.Context, baseline *Baseline) error
+}
+
+// baselineKeyPrefix prefixes baseline names in memory-backed stores
+const baselineKeyPrefix = "debug_agent_baseline:"
+
+// baselineNamePattern restricts baseline names so they are safe as file names
+var baselineNamePattern = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+// validateBaselineName checks that a baseline name can be used by every store
+func validateBaselineName(name string) error {
+	if !baselineNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid baseline name %q: use letters, digits, '.', '_' or '-'", name)
+	}
+	return nil
+}
+
+// FileBaselineStore keeps baselines as JSON files in a directory
+type FileBaselineStore struct {
+	dir string
+}
+
+// NewFileBaselineStore creates a store that writes "<name>.json" files to dir
+func NewFileBaselineStore(dir string) *FileBaselineStore {
+	return &FileBaselineStore{dir: dir}
+}
+
+// Load implements BaselineStore
+func (s *FileBaselineStore) Load(ctx context.Context, name string) (*Baseline, error) {
+	if err := validateBaselineName(name); err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(s.path(name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrBaselineNotFound, s.path(name))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline: %w", err)
+	}
+	return decodeBaseline(data)
+}
+
+// Save implements BaselineStore
+// The file is written atomically so an interrupted run never corrupts the baseline
+func (s *FileBaselineStore) Save(ctx context.Context, baseline *Baseline) error {
+	if err := validateBaselineName(baseline.Name); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(baseline, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode baseline: %w", err)
+	}
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create baseline directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(s.dir, baseline.Name+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write baseline: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write baseline: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write baseline: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path(baseline.Name)); err != nil {
+		return fmt.Errorf("failed to write baseline: %w", err)
+	}
+	return nil
+}
+
+// path returns the file of a named baseline
+func (s *FileBaselineStore) path(name string) string {
+	return filepath.Join(s.dir, name+".json")
+}
+
+// MissionBaselineStore keeps baselines in mission memory. When the mission has
+// memory continuity enabled, a baseline saved by a previous run is found as well.
+type MissionBaselineStore struct {
+	mission memory.MissionMemory
+}
+
+// NewMissionBaselineStore creates a store backed by mission memory
+func NewMissionBaselineStore(mission memory.MissionMemory) *MissionBaselineStore {
+	return &MissionBaselineStore{mission: mission}
+}
+
+// Load implements BaselineStore
+func (s *MissionBaselineStore) Load(ctx context.Context, name string) (*Baseline, error) {
+	key := baselineKeyPrefix + name
+
+	item, err := s.mission.Get(ctx, key)
+	if err == nil {
+		return decodeBaselineValue(item.Value)
+	}
+	if !errors.Is(err, memory.ErrNotFound) {
+		return nil, fmt.Errorf("failed to read baseline from mission memory: %w", err)
+	}
+
+	// Fall back to the previous run of the mission
+	value, err := s.mission.GetPreviousRunValue(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s (%v)", ErrBaselineNotFound, key, err)
+	}
+	return decodeBaselineValue(value)
+}
+
+// Save implements BaselineStore
+func (s *MissionBaselineStore) Save(ctx context.Context, baseline *Baseline) error {
+	data, err := json.Marshal(baseline)
+	if err != nil {
+		return fmt.Errorf("failed to encode baseline: %w", err)
+	}
+
+	metadata := map[string]any{
+		"type":     "debug_agent_baseline",
+		"baseline": baseline.Name,
+		"status":   string(baseline.OverallStatus),
+	}
+	if err := s.mission.Set(ctx, baselineKeyPrefix+baseline.Name, string(data), metadata); err != nil {
+		return fmt.Errorf("failed to save baseline to mission memory: %w", err)
+	}
+	return nil
+}
+
+// LongTermBaselineStore keeps baselines in long-term memory so they are shared
+// across missions. Every save adds a new entry; Load returns the newest one.
+type LongTermBaselineStore struct {
+	longTerm memory.LongTermMemory
+}
+
+// longTermBaselineSearchLimit bounds how many saved baselines Load considers
+const longTermBaselineSearchLimit = 50
+
+// NewLongTermBaselineStore creates a store backed by long-term memory
+func NewLongTermBaselineStore(longTerm memory.LongTermMemory) *LongTermBaselineStore {
+	return &LongTermBaselineStore{longTerm: longTerm}
+}
+
+// Load implements BaselineStore
+func (s *LongTermBaselineStore) Load(ctx context.Context, name string) (*Baseline, error) {
+	results, err := s.longTerm.Search(ctx, "debug agent baseline "+name, longTermBaselineSearchLimit,
+		map[string]any{"type": "debug_agent_baseline", "baseline": name})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search long-term memory for baseline: %w", err)
+	}
+
+	var latest *Baseline
+	for _, result := range results {
+		baseline, err := decodeBaselineValue(result.Value)
+		if err != nil || baseline.Name != name {
+			continue
+		}
+		if latest == nil || baseline.CreatedAt.After(latest.CreatedAt) {
+			latest = baseline
+		}
+	}
+	if latest == nil {
+		return nil, fmt.Errorf("%w: %s", ErrBaselineNotFound, name)
+	}
+	return latest, nil
+}
+
+// Save implements BaselineStore
+func (s *LongTermBaselineStore) Save(ctx context.Context, baseline *Baseline) error {
+	data, err := json.Marshal(baseline)
+	if err != nil {
+		return fmt.Errorf("failed to encode baseline: %w", err)
+	}
+
+	metadata := map[string]any{
+		"type":       "debug_agent_baseline",
+		"baseline":   baseline.Name,
+		"status":     string(baseline.OverallStatus),
+		"created_at": baseline.CreatedAt.Format(time.RFC3339),
+	}
+	if _, err := s.longTerm.Store(ctx, string(data), metadata); err != nil {
+		return fmt.Errorf("failed to save baseline to long-term memory: %w", err)
+	}
+	return nil
+}
+
+// decodeBaselineValue decodes a baseline stored in memory, which comes back
+// either as the JSON string that was saved or as an already decoded value
+func decodeBaselineValue(value any) (*Baseline, error) {
+	switch v := value.(type) {
+	case string:
+		return decodeBaseline([]byte(v))
+	case []byte:
+		return decodeBaseline(v)
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode baseline: %w", err)
+		}
+		return decodeBaseline(data)
+	}
+}
+
+// decodeBaseline parses a JSON baseline
+func decodeBaseline(data []byte) (*Baseline, error) {
+	var baseline Baseline
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return nil, fmt.Errorf("failed to decode baseline: %w", err)
+	}
+	if baseline.Version > baselineVersion {
+		return nil, fmt.Errorf("baseline format version %d is newer than supported version %d", baseline.Version, baselineVersion)
+	}
+	return &baseline, nil
+}
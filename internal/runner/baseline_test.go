@@ -0,0 +1,98 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// suiteOf builds a finalized suite from results
+func suiteOf(results ...TestResult) *SuiteResult {
+	suite := NewSuiteResult()
+	suite.AddResults(results)
+	suite.Finalize()
+	return suite
+}
+
+func resultWith(id string, status TestStatus, duration time.Duration) TestResult {
+	return TestResult{TestID: id, TestName: "Test " + id, Category: CategorySDK, Status: status, Duration: duration}
+}
+
+func TestCompare(t *testing.T) {
+	baseline := NewBaseline("latest", suiteOf(
+		resultWith("m/stable", TestStatusPass, time.Second),
+		resultWith("m/breaks", TestStatusPass, time.Second),
+		resultWith("m/fixed", TestStatusFail, time.Second),
+		resultWith("m/removed", TestStatusPass, time.Second),
+		resultWith("m/slow", TestStatusPass, 2*time.Second),
+		resultWith("m/jitter", TestStatusPass, 100*time.Millisecond),
+	))
+
+	comparison := Compare(baseline, suiteOf(
+		resultWith("m/stable", TestStatusPass, time.Second),
+		resultWith("m/breaks", TestStatusError, time.Second),
+		resultWith("m/fixed", TestStatusFlaky, time.Second),
+		resultWith("m/slow", TestStatusPass, 4*time.Second),
+		resultWith("m/jitter", TestStatusPass, 300*time.Millisecond),
+		resultWith("m/new", TestStatusPass, time.Second),
+	), DefaultCompareOptions)
+
+	if len(comparison.NewlyFailing) != 1 || comparison.NewlyFailing[0].ID != "m/breaks" {
+		t.Errorf("NewlyFailing = %+v, want m/breaks", comparison.NewlyFailing)
+	}
+	if len(comparison.NewlyPassing) != 1 || comparison.NewlyPassing[0].ID != "m/fixed" {
+		t.Errorf("NewlyPassing = %+v, want m/fixed", comparison.NewlyPassing)
+	}
+	if len(comparison.Disappeared) != 1 || comparison.Disappeared[0].ID != "m/removed" {
+		t.Errorf("Disappeared = %+v, want m/removed", comparison.Disappeared)
+	}
+	if len(comparison.Added) != 1 || comparison.Added[0] != "m/new" {
+		t.Errorf("Added = %v, want m/new", comparison.Added)
+	}
+	// m/jitter tripled but stayed below the minimum delta
+	if len(comparison.DurationRegressions) != 1 || comparison.DurationRegressions[0].ID != "m/slow" {
+		t.Errorf("DurationRegressions = %+v, want m/slow", comparison.DurationRegressions)
+	}
+	if !comparison.HasRegressions() {
+		t.Error("HasRegressions() = false, want true")
+	}
+
+	unchanged := Compare(baseline, suiteOf(
+		resultWith("m/stable", TestStatusPass, time.Second),
+		resultWith("m/breaks", TestStatusPass, time.Second),
+		resultWith("m/fixed", TestStatusFail, time.Second),
+		resultWith("m/removed", TestStatusSkip, 0),
+		resultWith("m/slow", TestStatusPass, 2*time.Second),
+		resultWith("m/jitter", TestStatusPass, 100*time.Millisecond),
+	), CompareOptions{})
+	if unchanged.HasRegressions() {
+		t.Errorf("HasRegressions() = true for unchanged run: %s", unchanged.Summary())
+	}
+}
+
+func TestFileBaselineStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewFileBaselineStore(t.TempDir())
+
+	if _, err := store.Load(ctx, "latest"); !errors.Is(err, ErrBaselineNotFound) {
+		t.Fatalf("Load() error = %v, want ErrBaselineNotFound", err)
+	}
+
+	saved := NewBaseline("latest", suiteOf(resultWith("m/a", TestStatusFail, 1500*time.Millisecond)))
+	if err := store.Save(ctx, saved); err != nil {
+		t.Fatalf("Save() unexpected error: %v", err)
+	}
+
+	loaded, err := store.Load(ctx, "latest")
+	if err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+	if loaded.Name != "latest" || len(loaded.Tests) != 1 || loaded.Tests[0] != saved.Tests[0] {
+		t.Errorf("Load() = %+v, want %+v", loaded, saved)
+	}
+
+	if err := store.Save(ctx, &Baseline{Name: "../escape"}); err == nil {
+		t.Error("Save() accepted a baseline name with a path separator")
+	}
+}
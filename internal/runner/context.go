@@ -0,0 +1,104 @@
+package runner
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// moduleRunKey is the context key under which the state of the executing module is stored
+type moduleRunKey struct{}
+
+// moduleRun carries per-module execution state from the runner into a module
+type moduleRun struct {
+	testTimeout time.Duration
+	retry       RetryPolicy
+	events      *eventBus
+	failFast    *failFast
+
+	// shuffle randomizes the order of test cases (nil keeps the declared order)
+	shuffle *rand.Rand
+
+	mu       sync.Mutex
+	recorded []TestResult
+	// announced counts results already published as test_finished events
+	announced int
+}
+
+// withModuleRun returns a context carrying the module execution state
+func withModuleRun(ctx context.Context, run *moduleRun) context.Context {
+	return context.WithValue(ctx, moduleRunKey{}, run)
+}
+
+// moduleRunFrom returns the module execution state from a context (nil if absent)
+func moduleRunFrom(ctx context.Context) *moduleRun {
+	run, _ := ctx.Value(moduleRunKey{}).(*moduleRun)
+	return run
+}
+
+// record stores results reported by the module
+func (m *moduleRun) record(results ...TestResult) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.recorded = append(m.recorded, results...)
+}
+
+// testStarted publishes a test_started event
+func (m *moduleRun) testStarted(ctx context.Context, module TestModule, testID, testName string) {
+	m.events.testStarted(ctx, module, testID, testName)
+}
+
+// testFinished publishes a test_finished event as soon as a test completes
+func (m *moduleRun) testFinished(ctx context.Context, module TestModule, result TestResult) {
+	m.events.testFinished(ctx, module, result)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.announced++
+}
+
+// unannounced returns the results that have not been published as events yet
+// Results published while the module ran always come first in its results
+func (m *moduleRun) unannounced(results []TestResult) []TestResult {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.announced >= len(results) {
+		return nil
+	}
+	return results[m.announced:]
+}
+
+// results returns a copy of the results reported so far
+func (m *moduleRun) results() []TestResult {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]TestResult{}, m.recorded...)
+}
+
+// TestContext derives the context for a single test from the module context.
+// The test deadline is the runner's per-test timeout (TestTimeout); tests known to
+// need longer, such as active network scans, pass a minimum that extends it.
+// The deadline never outlives the module context, so suite and category timeouts
+// still cancel the test. Callers must call the returned cancel function.
+func TestContext(ctx context.Context, minimum time.Duration) (context.Context, context.CancelFunc) {
+	timeout := minimum
+	if run := moduleRunFrom(ctx); run != nil && run.testTimeout > timeout {
+		timeout = run.testTimeout
+	}
+
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// Report records results as soon as a module produces them.
+// If the module is cancelled or times out before returning, the runner keeps the
+// reported results instead of discarding everything the module did. When the module
+// returns normally its returned results are used and reported results are ignored.
+func Report(ctx context.Context, results ...TestResult) {
+	if run := moduleRunFrom(ctx); run != nil {
+		run.record(results...)
+	}
+}
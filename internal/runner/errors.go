@@ -0,0 +1,203 @@
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrorKind classifies the cause of a failed or errored test
+type ErrorKind string
+
+const (
+	// ErrorKindTimeout indicates the test or its module exceeded a deadline
+	ErrorKindTimeout ErrorKind = "timeout"
+
+	// ErrorKindCancelled indicates the run was cancelled before the test finished
+	ErrorKindCancelled ErrorKind = "cancelled"
+
+	// ErrorKindPanic indicates the test panicked
+	ErrorKindPanic ErrorKind = "panic"
+
+	// ErrorKindAssertion indicates an expectation of the test was not met
+	ErrorKindAssertion ErrorKind = "assertion"
+
+	// ErrorKindHarness indicates a harness call (LLM, memory, GraphRAG, findings) failed
+	ErrorKindHarness ErrorKind = "harness"
+
+	// ErrorKindTool indicates a tool invoked through the harness failed
+	ErrorKindTool ErrorKind = "tool"
+
+	// ErrorKindUnknown is used for errors that carry no classification
+	ErrorKindUnknown ErrorKind = "error"
+)
+
+// TestError is a structured, serializable description of why a test failed.
+// It wraps the original error, so errors.Is and errors.As keep working.
+type TestError struct {
+	// Kind classifies the failure
+	Kind ErrorKind `json:"kind"`
+
+	// Message is the full error message
+	Message string `json:"message"`
+
+	// Chain lists the messages of the wrapped causes, outermost first
+	Chain []string `json:"chain,omitempty"`
+
+	// Stack is the goroutine stack for panics
+	Stack string `json:"stack,omitempty"`
+
+	// HarnessCall names the harness method (and tool) that failed, e.g. "CallToolsParallel(nmap)"
+	HarnessCall string `json:"harness_call,omitempty"`
+
+	err error
+}
+
+// Error implements error
+func (e *TestError) Error() string {
+	return e.Message
+}
+
+// Unwrap returns the original error
+func (e *TestError) Unwrap() error {
+	return e.err
+}
+
+// newTestError classifies err with the given kind
+func newTestError(kind ErrorKind, err error) *TestError {
+	return &TestError{
+		Kind:    kind,
+		Message: err.Error(),
+		Chain:   errorChain(err),
+		err:     err,
+	}
+}
+
+// HarnessError marks err as the failure of a harness call (nil if err is nil)
+func HarnessError(call string, err error) error {
+	if err == nil {
+		return nil
+	}
+	te := newTestError(ErrorKindHarness, err)
+	te.HarnessCall = call
+	return te
+}
+
+// ToolError marks err as the failure of a tool invoked through a harness call (nil if err is nil)
+func ToolError(call, tool string, err error) error {
+	if err == nil {
+		return nil
+	}
+	te := newTestError(ErrorKindTool, err)
+	te.HarnessCall = fmt.Sprintf("%s(%s)", call, tool)
+	return te
+}
+
+// AssertionError returns an error describing an unmet expectation
+func AssertionError(format string, args ...any) error {
+	return newTestError(ErrorKindAssertion, fmt.Errorf(format, args...))
+}
+
+// PanicError describes a recovered panic together with the stack where it occurred
+func PanicError(value any, stack []byte) *TestError {
+	err, ok := value.(error)
+	if !ok {
+		err = fmt.Errorf("%v", value)
+	}
+	return &TestError{
+		Kind:    ErrorKindPanic,
+		Message: fmt.Sprint(value),
+		Chain:   errorChain(err),
+		Stack:   string(stack),
+		err:     err,
+	}
+}
+
+// AsTestError returns the structured form of err (nil if err is nil).
+// Errors that already contain a TestError keep its kind, stack and harness call
+// with the full message of err; other errors are classified by their cause.
+func AsTestError(err error) *TestError {
+	if err == nil {
+		return nil
+	}
+
+	var inner *TestError
+	if errors.As(err, &inner) {
+		if inner == err {
+			return inner
+		}
+		te := *inner
+		te.Message = err.Error()
+		te.Chain = errorChain(err)
+		te.err = err
+		return &te
+	}
+
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return newTestError(ErrorKindTimeout, err)
+	case errors.Is(err, context.Canceled):
+		return newTestError(ErrorKindCancelled, err)
+	default:
+		return newTestError(ErrorKindUnknown, err)
+	}
+}
+
+// errorChain returns the messages of the errors wrapped by err, outermost first.
+// Causes whose message merely repeats the previous one are left out.
+func errorChain(err error) []string {
+	var chain []string
+	previous := err.Error()
+
+	for cause := unwrapOne(err); cause != nil; cause = unwrapOne(cause) {
+		message := cause.Error()
+		if message != previous {
+			chain = append(chain, message)
+		}
+		previous = message
+	}
+	return chain
+}
+
+// unwrapOne returns the cause of err, taking the first of joined errors
+func unwrapOne(err error) error {
+	switch e := err.(type) {
+	case interface{ Unwrap() error }:
+		return e.Unwrap()
+	case interface{ Unwrap() []error }:
+		if causes := e.Unwrap(); len(causes) > 0 {
+			return causes[0]
+		}
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, rendering Error as a TestError
+// (a plain error would otherwise serialize as an empty object)
+func (tr TestResult) MarshalJSON() ([]byte, error) {
+	type plain TestResult
+	return json.Marshal(struct {
+		plain
+		Error *TestError
+	}{plain(tr), AsTestError(tr.Error)})
+}
+
+// UnmarshalJSON implements json.Unmarshaler for results written by MarshalJSON
+func (tr *TestResult) UnmarshalJSON(data []byte) error {
+	type plain TestResult
+	var decoded struct {
+		plain
+		Error *TestError
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	*tr = TestResult(decoded.plain)
+	tr.Error = nil
+	if decoded.Error != nil {
+		tr.Error = decoded.Error
+	}
+	return nil
+}
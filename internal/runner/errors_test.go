@@ -0,0 +1,148 @@
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zero-day-ai/sdk/agent"
+)
+
+func TestAsTestError(t *testing.T) {
+	refused := errors.New("connection refused")
+
+	tests := []struct {
+		name        string
+		err         error
+		wantKind    ErrorKind
+		wantCall    string
+		wantMessage string
+		wantChain   []string
+	}{
+		{
+			name:        "deadline",
+			err:         fmt.Errorf("module timed out: %w", context.DeadlineExceeded),
+			wantKind:    ErrorKindTimeout,
+			wantMessage: "module timed out: context deadline exceeded",
+			wantChain:   []string{"context deadline exceeded"},
+		},
+		{
+			name:        "cancelled",
+			err:         fmt.Errorf("test not started: %w", context.Canceled),
+			wantKind:    ErrorKindCancelled,
+			wantMessage: "test not started: context canceled",
+			wantChain:   []string{"context canceled"},
+		},
+		{
+			name:        "wrapped harness error",
+			err:         fmt.Errorf("store failed: %w", HarnessError("StoreGraphBatch", refused)),
+			wantKind:    ErrorKindHarness,
+			wantCall:    "StoreGraphBatch",
+			wantMessage: "store failed: connection refused",
+			wantChain:   []string{"connection refused"},
+		},
+		{
+			name:        "tool error",
+			err:         ToolError("CallToolsParallel", "nmap", refused),
+			wantKind:    ErrorKindTool,
+			wantCall:    "CallToolsParallel(nmap)",
+			wantMessage: "connection refused",
+		},
+		{
+			name:        "assertion",
+			err:         AssertionError("expected %d hosts, got %d", 3, 1),
+			wantKind:    ErrorKindAssertion,
+			wantMessage: "expected 3 hosts, got 1",
+		},
+		{
+			name:        "plain error",
+			err:         refused,
+			wantKind:    ErrorKindUnknown,
+			wantMessage: "connection refused",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := AsTestError(tt.err)
+			if got.Kind != tt.wantKind || got.HarnessCall != tt.wantCall || got.Message != tt.wantMessage {
+				t.Errorf("AsTestError() = {%s %q %q}, want {%s %q %q}",
+					got.Kind, got.HarnessCall, got.Message, tt.wantKind, tt.wantCall, tt.wantMessage)
+			}
+			if !slices.Equal(got.Chain, tt.wantChain) {
+				t.Errorf("Chain = %q, want %q", got.Chain, tt.wantChain)
+			}
+			if !errors.Is(got, tt.err) && !errors.Is(tt.err, got) {
+				t.Error("TestError does not wrap the original error")
+			}
+		})
+	}
+
+	if AsTestError(nil) != nil {
+		t.Error("AsTestError(nil) != nil")
+	}
+}
+
+func TestTestResult_JSONRoundTrip(t *testing.T) {
+	original := NewFailResult("Ping Sweep", "NR-2", CategorySDK, time.Second, "no hosts",
+		ToolError("CallToolsParallel", "ping", errors.New("exit status 2")))
+
+	data, err := json.Marshal(original)
+	if err != nil {
+		t.Fatalf("Marshal() unexpected error: %v", err)
+	}
+	if !strings.Contains(string(data), `"Error":{"kind":"tool","message":"exit status 2","harness_call":"CallToolsParallel(ping)"}`) {
+		t.Errorf("Marshal() = %s, want structured error", data)
+	}
+
+	var decoded TestResult
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal() unexpected error: %v", err)
+	}
+	testErr := AsTestError(decoded.Error)
+	if decoded.TestName != "Ping Sweep" || testErr == nil || testErr.Kind != ErrorKindTool || testErr.Message != "exit status 2" {
+		t.Errorf("Unmarshal() = %+v with error %+v", decoded, testErr)
+	}
+
+	pass, err := json.Marshal(NewPassResult("ok", "", CategorySDK, 0, "ok"))
+	if err != nil || !strings.Contains(string(pass), `"Error":null`) {
+		t.Errorf("Marshal() of passing result = %s, %v", pass, err)
+	}
+}
+
+func TestRun_TestCasePanicIsStructured(t *testing.T) {
+	module := newCaseModule("m", TestCase{
+		ID:   "panics",
+		Name: "Test panics",
+		Run: func(ctx context.Context, h agent.Harness) TestResult {
+			var hosts map[string]int
+			hosts["10.0.0.1"]++
+			return TestResult{}
+		},
+	})
+
+	r := NewRunner(newStubHarness(), time.Minute, time.Second)
+	if err := r.RegisterModule(module); err != nil {
+		t.Fatal(err)
+	}
+	suite, err := r.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+
+	testErr := AsTestError(suite.Results[0].Error)
+	if testErr == nil || testErr.Kind != ErrorKindPanic {
+		t.Fatalf("error = %+v, want panic", testErr)
+	}
+	if !strings.Contains(testErr.Message, "assignment to entry in nil map") {
+		t.Errorf("Message = %q, want panic value", testErr.Message)
+	}
+	if !strings.Contains(testErr.Stack, "errors_test.go") {
+		t.Errorf("Stack does not point at the panicking test:\n%s", testErr.Stack)
+	}
+}
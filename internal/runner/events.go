@@ -0,0 +1,218 @@
+package runner
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// EventType identifies a point in the suite lifecycle
+type EventType string
+
+const (
+	// EventSuiteStarted is emitted before the first module starts
+	EventSuiteStarted EventType = "suite_started"
+
+	// EventModuleStarted is emitted when a module begins executing
+	EventModuleStarted EventType = "module_started"
+
+	// EventTestStarted is emitted before a test case runs
+	EventTestStarted EventType = "test_started"
+
+	// EventTestFinished is emitted for every test result, including skips
+	EventTestFinished EventType = "test_finished"
+
+	// EventModuleFinished is emitted when a module has produced all of its results
+	EventModuleFinished EventType = "module_finished"
+
+	// EventSuiteFinished is emitted after the suite result is finalized
+	EventSuiteFinished EventType = "suite_finished"
+)
+
+// Progress is a snapshot of how far the suite has progressed
+type Progress struct {
+	// ModulesTotal is the number of modules in the run
+	ModulesTotal int
+
+	// ModulesCompleted is the number of modules that have finished
+	ModulesCompleted int
+
+	// TestsTotal is the expected number of tests (modules without test cases count as one)
+	TestsTotal int
+
+	// TestsCompleted is the number of test results produced so far
+	TestsCompleted int
+
+	// Failed is the number of failed or errored tests so far
+	Failed int
+}
+
+// Event describes a change in suite execution state
+type Event struct {
+	// Type is the kind of event
+	Type EventType
+
+	// Time is when the event occurred
+	Time time.Time
+
+	// Module is the module the event relates to (empty for suite events)
+	Module string
+
+	// Category is the category of the module (empty for suite events)
+	Category Category
+
+	// TestID is the qualified test ID for test events
+	TestID string
+
+	// TestName is the test name for test events
+	TestName string
+
+	// Status is the test outcome for test_finished and the suite outcome for suite_finished
+	Status TestStatus
+
+	// Result is the test result for test_finished events
+	Result *TestResult
+
+	// Suite is the finalized suite for suite_finished events
+	Suite *SuiteResult
+
+	// Progress is the suite progress at the time of the event
+	Progress Progress
+}
+
+// Observer receives suite execution events.
+// Events are delivered one at a time and in order, so observers need not be
+// safe for concurrent use, but they should return quickly since they delay
+// the tests that emit them.
+type Observer interface {
+	Observe(ctx context.Context, event Event)
+}
+
+// ObserverFunc adapts a function to the Observer interface
+type ObserverFunc func(ctx context.Context, event Event)
+
+// Observe implements Observer
+func (f ObserverFunc) Observe(ctx context.Context, event Event) {
+	f(ctx, event)
+}
+
+// eventBus serializes event delivery to observers and tracks progress
+type eventBus struct {
+	mu        sync.Mutex
+	observers []Observer
+	progress  Progress
+	onPanic   func(observer Observer, event Event, panicErr any)
+}
+
+// add registers an observer
+func (b *eventBus) add(observer Observer) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.observers = append(b.observers, observer)
+}
+
+// start resets progress for a new run over the given modules
+func (b *eventBus) start(modules []TestModule) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.progress = Progress{ModulesTotal: len(modules)}
+	for _, module := range modules {
+		if cases := moduleCases(module); cases != nil {
+			b.progress.TestsTotal += len(cases)
+		} else {
+			b.progress.TestsTotal++
+		}
+	}
+}
+
+// emit updates progress and delivers an event to every observer
+// A nil bus discards events, e.g. for modules run outside of a Runner
+func (b *eventBus) emit(ctx context.Context, event Event) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch event.Type {
+	case EventTestFinished:
+		b.progress.TestsCompleted++
+		if event.Status == TestStatusFail || event.Status == TestStatusError {
+			b.progress.Failed++
+		}
+		// Modules without test cases, or module-level errors, may produce more
+		// results than expected
+		if b.progress.TestsCompleted > b.progress.TestsTotal {
+			b.progress.TestsTotal = b.progress.TestsCompleted
+		}
+	case EventModuleFinished:
+		b.progress.ModulesCompleted++
+	}
+
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	event.Progress = b.progress
+
+	for _, observer := range b.observers {
+		b.deliver(ctx, observer, event)
+	}
+}
+
+// deliver calls one observer, isolating the runner from observer panics
+func (b *eventBus) deliver(ctx context.Context, observer Observer, event Event) {
+	defer func() {
+		if panicErr := recover(); panicErr != nil && b.onPanic != nil {
+			b.onPanic(observer, event, panicErr)
+		}
+	}()
+	observer.Observe(ctx, event)
+}
+
+// testStarted emits a test_started event
+func (b *eventBus) testStarted(ctx context.Context, module TestModule, testID, testName string) {
+	b.emit(ctx, Event{
+		Type:     EventTestStarted,
+		Module:   module.Name(),
+		Category: module.Category(),
+		TestID:   testID,
+		TestName: testName,
+	})
+}
+
+// testFinished emits a test_finished event for a result
+func (b *eventBus) testFinished(ctx context.Context, module TestModule, result TestResult) {
+	b.emit(ctx, Event{
+		Type:     EventTestFinished,
+		Module:   module.Name(),
+		Category: module.Category(),
+		TestID:   result.TestID,
+		TestName: result.TestName,
+		Status:   result.Status,
+		Result:   &result,
+	})
+}
+
+// moduleStarted emits a module_started event
+func (b *eventBus) moduleStarted(ctx context.Context, module TestModule) {
+	b.emit(ctx, Event{
+		Type:     EventModuleStarted,
+		Module:   module.Name(),
+		Category: module.Category(),
+	})
+}
+
+// moduleFinished emits test_finished events for results not yet announced,
+// followed by a module_finished event
+func (b *eventBus) moduleFinished(ctx context.Context, module TestModule, pending []TestResult) {
+	for _, result := range pending {
+		b.testFinished(ctx, module, result)
+	}
+	b.emit(ctx, Event{
+		Type:     EventModuleFinished,
+		Module:   module.Name(),
+		Category: module.Category(),
+	})
+}
@@ -0,0 +1,148 @@
+package runner
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// eventRecorder collects events delivered to it
+type eventRecorder struct {
+	events []Event
+}
+
+func (e *eventRecorder) Observe(ctx context.Context, event Event) {
+	e.events = append(e.events, event)
+}
+
+func (e *eventRecorder) types() []EventType {
+	types := make([]EventType, len(e.events))
+	for i, event := range e.events {
+		types[i] = event.Type
+	}
+	return types
+}
+
+func TestRun_EmitsEvents(t *testing.T) {
+	var ran []string
+	recorder := &eventRecorder{}
+
+	r := NewRunner(newStubHarness(), time.Minute, time.Second)
+	r.AddObserver(recorder)
+	err := r.RegisterModules(
+		newCaseModule("cases",
+			recordingCase("a", TestStatusPass, &ran),
+			recordingCase("b", TestStatusFail, &ran),
+		),
+		newFakeModule("plain", "cases"),
+	)
+	if err != nil {
+		t.Fatalf("RegisterModules() unexpected error: %v", err)
+	}
+
+	if _, err := r.Run(context.Background()); err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+
+	want := []EventType{
+		EventSuiteStarted,
+		EventModuleStarted, EventTestStarted, EventTestFinished, EventTestStarted, EventTestFinished, EventModuleFinished,
+		EventModuleStarted, EventTestFinished, EventModuleFinished,
+		EventSuiteFinished,
+	}
+	got := recorder.types()
+	if len(got) != len(want) {
+		t.Fatalf("events = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("events = %v, want %v", got, want)
+		}
+	}
+
+	last := recorder.events[len(recorder.events)-1]
+	if last.Suite == nil || last.Status != TestStatusFail {
+		t.Errorf("suite_finished status = %s (suite %v), want fail with suite", last.Status, last.Suite != nil)
+	}
+	wantProgress := Progress{ModulesTotal: 2, ModulesCompleted: 2, TestsTotal: 3, TestsCompleted: 3, Failed: 1}
+	if last.Progress != wantProgress {
+		t.Errorf("final progress = %+v, want %+v", last.Progress, wantProgress)
+	}
+	if finished := recorder.events[5]; finished.TestID != "cases/b" || finished.Result == nil {
+		t.Errorf("test_finished event = %+v, want cases/b with result", finished)
+	}
+}
+
+func TestRun_ObserverPanicIsContained(t *testing.T) {
+	r := NewRunner(newStubHarness(), time.Minute, time.Second)
+	r.AddObserver(ObserverFunc(func(ctx context.Context, event Event) {
+		panic("observer bug")
+	}))
+	if err := r.RegisterModule(newFakeModule("a")); err != nil {
+		t.Fatalf("RegisterModule() unexpected error: %v", err)
+	}
+
+	suite, err := r.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+	if suite.OverallStatus != TestStatusPass {
+		t.Errorf("OverallStatus = %s, want pass", suite.OverallStatus)
+	}
+}
+
+// fakeWorkingMemory is an in-memory memory.WorkingMemory
+type fakeWorkingMemory struct {
+	mu     sync.Mutex
+	values map[string]any
+}
+
+func (f *fakeWorkingMemory) Get(ctx context.Context, key string) (any, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.values[key], nil
+}
+
+func (f *fakeWorkingMemory) Set(ctx context.Context, key string, value any) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.values == nil {
+		f.values = make(map[string]any)
+	}
+	f.values[key] = value
+	return nil
+}
+
+func (f *fakeWorkingMemory) Delete(ctx context.Context, key string) error { return nil }
+func (f *fakeWorkingMemory) Clear(ctx context.Context) error              { return nil }
+func (f *fakeWorkingMemory) Keys(ctx context.Context) ([]string, error)   { return nil, nil }
+
+func TestMemoryObserver_PublishesProgress(t *testing.T) {
+	working := &fakeWorkingMemory{}
+
+	r := NewRunner(newStubHarness(), time.Minute, time.Second)
+	r.AddObserver(NewMemoryObserver(working, "", nil))
+	if err := r.RegisterModules(newFakeModule("a"), newFakeModule("b")); err != nil {
+		t.Fatalf("RegisterModules() unexpected error: %v", err)
+	}
+
+	if _, err := r.Run(context.Background()); err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+
+	value, _ := working.Get(context.Background(), DefaultProgressKey)
+	snapshot, ok := value.(map[string]any)
+	if !ok {
+		t.Fatalf("progress snapshot = %T, want map", value)
+	}
+	if snapshot["status"] != string(TestStatusPass) {
+		t.Errorf("status = %v, want pass", snapshot["status"])
+	}
+	if snapshot["tests_completed"] != 2 || snapshot["modules_completed"] != 2 {
+		t.Errorf("progress = %v tests / %v modules, want 2/2", snapshot["tests_completed"], snapshot["modules_completed"])
+	}
+	if running := snapshot["running_modules"].([]string); len(running) != 0 {
+		t.Errorf("running_modules = %v, want none", running)
+	}
+}
@@ -0,0 +1,101 @@
+package runner
+
+import (
+	"log/slog"
+	"sync"
+)
+
+// FailFastScope selects which remaining tests are aborted after the first failure
+type FailFastScope string
+
+const (
+	// FailFastOff runs every test regardless of failures
+	FailFastOff FailFastScope = ""
+
+	// FailFastSuite aborts the rest of the suite after the first failure
+	FailFastSuite FailFastScope = "suite"
+
+	// FailFastCategory aborts the rest of the failing test's category
+	FailFastCategory FailFastScope = "category"
+
+	// FailFastModule aborts the rest of the failing test's module
+	FailFastModule FailFastScope = "module"
+)
+
+// failFast tracks the first failure per scope during one run. Tests already
+// executing when a failure is recorded finish normally; tests that have not
+// started yet are reported as skipped.
+type failFast struct {
+	scope  FailFastScope
+	logger *slog.Logger
+
+	mu sync.Mutex
+	// failedBy maps a scope key to the ID of the first test that failed in it
+	failedBy map[string]string
+}
+
+// newFailFast creates the fail-fast state for one run
+func newFailFast(scope FailFastScope, logger *slog.Logger) *failFast {
+	return &failFast{
+		scope:    scope,
+		logger:   logger,
+		failedBy: make(map[string]string),
+	}
+}
+
+// key returns the scope a module's tests belong to
+func (f *failFast) key(module TestModule) string {
+	switch f.scope {
+	case FailFastCategory:
+		return string(module.Category())
+	case FailFastModule:
+		return module.Name()
+	}
+	return ""
+}
+
+// record notes the first failed or errored result of each scope
+func (f *failFast) record(module TestModule, results ...TestResult) {
+	if f == nil || f.scope == FailFastOff {
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	key := f.key(module)
+	if _, failed := f.failedBy[key]; failed {
+		return
+	}
+	for _, result := range results {
+		if result.Status != TestStatusFail && result.Status != TestStatusError {
+			continue
+		}
+		test := result.TestID
+		if test == "" {
+			test = result.TestName
+		}
+		f.failedBy[key] = test
+		f.logger.Warn("Fail-fast triggered, aborting remaining tests",
+			"scope", f.scope,
+			"failed_test", test,
+			"module", module.Name(),
+		)
+		return
+	}
+}
+
+// aborted returns a skip error for tests of a module whose scope already failed
+func (f *failFast) aborted(module TestModule) error {
+	if f == nil || f.scope == FailFastOff {
+		return nil
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if test, failed := f.failedBy[f.key(module)]; failed {
+		return Skip("Aborted after failure of %s", test)
+	}
+	return nil
+}
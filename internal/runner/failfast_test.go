@@ -0,0 +1,84 @@
+package runner
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRun_FailFast(t *testing.T) {
+	const aborted = "Aborted after failure of sdk-a/a1"
+
+	tests := []struct {
+		name  string
+		scope FailFastScope
+		want  map[string]string
+	}{
+		{
+			name:  "off",
+			scope: FailFastOff,
+			want:  map[string]string{"sdk-a/a2": "", "sdk-b/b1": "", "framework-c/c1": ""},
+		},
+		{
+			name:  "suite",
+			scope: FailFastSuite,
+			want:  map[string]string{"sdk-a/a2": aborted, "sdk-b/b1": aborted, "framework-c/c1": aborted},
+		},
+		{
+			name:  "category",
+			scope: FailFastCategory,
+			want:  map[string]string{"sdk-a/a2": aborted, "sdk-b/b1": aborted, "framework-c/c1": ""},
+		},
+		{
+			name:  "module",
+			scope: FailFastModule,
+			want:  map[string]string{"sdk-a/a2": aborted, "sdk-b/b1": "", "framework-c/c1": ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ran []string
+			a := newCaseModule("sdk-a",
+				recordingCase("a1", TestStatusFail, &ran),
+				recordingCase("a2", TestStatusPass, &ran))
+			b := newCaseModule("sdk-b", recordingCase("b1", TestStatusPass, &ran))
+			b.deps = []string{"sdk-a"}
+			c := newCaseModule("framework-c", recordingCase("c1", TestStatusPass, &ran))
+			c.category = CategoryFramework
+			c.deps = []string{"sdk-b"}
+
+			r := NewRunner(newStubHarness(), time.Minute, time.Second)
+			r.SetFailFast(tt.scope)
+			if err := r.RegisterModules(a, b, c); err != nil {
+				t.Fatal(err)
+			}
+
+			suite, err := r.Run(context.Background())
+			if err != nil {
+				t.Fatalf("Run() unexpected error: %v", err)
+			}
+
+			for _, result := range suite.Results {
+				reason, ok := tt.want[result.TestID]
+				if !ok {
+					continue
+				}
+				if reason == "" {
+					if result.Status != TestStatusPass {
+						t.Errorf("%s: Status = %s (%q), want pass", result.TestID, result.Status, result.Message)
+					}
+					continue
+				}
+				if result.Status != TestStatusSkip || result.Message != reason {
+					t.Errorf("%s: = %s %q, want skip %q", result.TestID, result.Status, result.Message, reason)
+				}
+				if _, id, _ := strings.Cut(result.TestID, "/"); slices.Contains(ran, id) {
+					t.Errorf("%s ran although it was aborted", result.TestID)
+				}
+			}
+		})
+	}
+}
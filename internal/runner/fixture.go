@@ -0,0 +1,213 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/zero-day-ai/sdk/agent"
+)
+
+// Scope identifies the lifetime of a fixture
+type Scope string
+
+const (
+	// ScopeSuite fixtures live until every module in the run has finished
+	ScopeSuite Scope = "suite"
+
+	// ScopeModule fixtures live until the module has finished
+	ScopeModule Scope = "module"
+
+	// ScopeTest fixtures live for a single test attempt
+	ScopeTest Scope = "test"
+)
+
+// fixtureReleaseTimeout bounds each fixture release and teardown hook.
+// Releases run on a fresh context so they still happen after a timeout.
+const fixtureReleaseTimeout = 30 * time.Second
+
+// SuiteHooks is implemented by modules that need state shared across the whole run.
+// SetupSuite runs before any module starts; TeardownSuite and the suite fixtures run
+// after every module has finished.
+type SuiteHooks interface {
+	SetupSuite(ctx context.Context, h agent.Harness, fixtures *Fixtures) error
+	TeardownSuite(ctx context.Context, h agent.Harness) error
+}
+
+// ModuleHooks is implemented by modules with preconditions or state shared by their tests.
+// SetupModule runs before the module's first test; TeardownModule and the module
+// fixtures run after its last test, even if the module panicked or timed out.
+type ModuleHooks interface {
+	SetupModule(ctx context.Context, h agent.Harness, fixtures *Fixtures) error
+	TeardownModule(ctx context.Context, h agent.Harness) error
+}
+
+// TestHooks is implemented by modules that prepare and clean up around each test case.
+// Hooks run around every attempt of a retried test.
+type TestHooks interface {
+	SetupTest(ctx context.Context, h agent.Harness, test TestRef, fixtures *Fixtures) error
+	TeardownTest(ctx context.Context, h agent.Harness, test TestRef) error
+}
+
+// SkipError is returned by a setup hook when a precondition is not met.
+// The affected tests are reported as skipped instead of errored.
+type SkipError struct {
+	Reason string
+}
+
+// Error implements error
+func (e *SkipError) Error() string {
+	return e.Reason
+}
+
+// Skip returns a SkipError with a formatted reason
+func Skip(format string, args ...any) error {
+	return &SkipError{Reason: fmt.Sprintf(format, args...)}
+}
+
+// skipReason returns the reason if err is a SkipError
+func skipReason(err error) (string, bool) {
+	var skip *SkipError
+	if errors.As(err, &skip) {
+		return skip.Reason, true
+	}
+	return "", false
+}
+
+// fixture is a named release function
+type fixture struct {
+	name    string
+	release func(ctx context.Context) error
+}
+
+// Fixtures collects resources acquired within a scope and releases them in reverse
+// order of acquisition when the scope ends. It is safe for concurrent use.
+type Fixtures struct {
+	scope  Scope
+	logger *slog.Logger
+
+	mu       sync.Mutex
+	fixtures []fixture
+	released bool
+}
+
+// newFixtures creates an empty fixture set for a scope
+func newFixtures(scope Scope, logger *slog.Logger) *Fixtures {
+	return &Fixtures{scope: scope, logger: logger}
+}
+
+// Scope returns the lifetime of the fixtures in this set
+func (f *Fixtures) Scope() Scope {
+	return f.scope
+}
+
+// Add registers a release function for a resource acquired in this scope.
+// If the scope has already ended (e.g. a timed-out module that is still running),
+// the resource is released immediately so it can never leak.
+func (f *Fixtures) Add(name string, release func(ctx context.Context) error) {
+	f.mu.Lock()
+	if !f.released {
+		f.fixtures = append(f.fixtures, fixture{name: name, release: release})
+		f.mu.Unlock()
+		return
+	}
+	f.mu.Unlock()
+
+	if err := releaseFixture(context.Background(), fixture{name: name, release: release}); err != nil {
+		f.logger.Warn("Failed to release late fixture",
+			"scope", f.scope,
+			"fixture", name,
+			"error", err,
+		)
+	}
+}
+
+// release releases all fixtures in reverse order and returns the combined error.
+// Every fixture is released even if earlier ones fail or panic.
+func (f *Fixtures) release(ctx context.Context) error {
+	f.mu.Lock()
+	fixtures := f.fixtures
+	f.fixtures = nil
+	f.released = true
+	f.mu.Unlock()
+
+	var errs []error
+	for i := len(fixtures) - 1; i >= 0; i-- {
+		if err := releaseFixture(ctx, fixtures[i]); err != nil {
+			f.logger.Warn("Failed to release fixture",
+				"scope", f.scope,
+				"fixture", fixtures[i].name,
+				"error", err,
+			)
+			errs = append(errs, fmt.Errorf("release %s: %w", fixtures[i].name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// releaseFixture calls a single release function on a fresh, bounded context
+func releaseFixture(ctx context.Context, fx fixture) error {
+	return callHook(ctx, func(ctx context.Context) error {
+		return fx.release(ctx)
+	})
+}
+
+// callHook runs a setup, teardown or release function with panic recovery.
+// The function gets a context that survives cancellation of ctx but is bounded
+// by fixtureReleaseTimeout, so cleanup still happens after a timeout.
+func callHook(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	hookCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), fixtureReleaseTimeout)
+	defer cancel()
+
+	defer func() {
+		if panicErr := recover(); panicErr != nil {
+			err = fmt.Errorf("panic: %w", PanicError(panicErr, debug.Stack()))
+		}
+	}()
+
+	return fn(hookCtx)
+}
+
+// callSetup runs a setup hook with panic recovery on the scope's own context,
+// so a setup that outlives its deadline is cancelled like a test would be
+func callSetup(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	defer func() {
+		if panicErr := recover(); panicErr != nil {
+			err = fmt.Errorf("panic: %w", PanicError(panicErr, debug.Stack()))
+		}
+	}()
+
+	return fn(ctx)
+}
+
+// fixturesKey is the context key for the innermost fixture scope
+type fixturesKey struct{}
+
+// withFixtures returns a context whose innermost fixture scope is f
+func withFixtures(ctx context.Context, f *Fixtures) context.Context {
+	return context.WithValue(ctx, fixturesKey{}, f)
+}
+
+// FixturesFrom returns the innermost fixture scope of the context (nil if none):
+// the test scope while a test case runs, otherwise the module scope
+func FixturesFrom(ctx context.Context) *Fixtures {
+	f, _ := ctx.Value(fixturesKey{}).(*Fixtures)
+	return f
+}
+
+// Cleanup registers release to run when the innermost fixture scope ends, like
+// testing.T.Cleanup. It reports false if the context carries no fixture scope
+// (the module runs outside of a Runner), in which case the caller must release
+// the resource itself.
+func Cleanup(ctx context.Context, name string, release func(ctx context.Context) error) bool {
+	f := FixturesFrom(ctx)
+	if f == nil {
+		return false
+	}
+	f.Add(name, release)
+	return true
+}
@@ -0,0 +1,281 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/zero-day-ai/sdk/agent"
+)
+
+// callLog records hook and fixture calls from concurrently running modules
+type callLog struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (l *callLog) add(call string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.calls = append(l.calls, call)
+}
+
+func (l *callLog) get() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return slices.Clone(l.calls)
+}
+
+// hookModule is a caseModule implementing every hook interface
+type hookModule struct {
+	caseModule
+	log         *callLog
+	setupModule error
+	setupTest   func(test TestRef) error
+	suiteSetups int
+}
+
+func (m *hookModule) SetupSuite(ctx context.Context, h agent.Harness, fixtures *Fixtures) error {
+	m.suiteSetups++
+	m.log.add("setup-suite")
+	fixtures.Add("suite", func(ctx context.Context) error {
+		m.log.add("release-suite")
+		return nil
+	})
+	return nil
+}
+
+func (m *hookModule) TeardownSuite(ctx context.Context, h agent.Harness) error {
+	m.log.add("teardown-suite")
+	return nil
+}
+
+func (m *hookModule) SetupModule(ctx context.Context, h agent.Harness, fixtures *Fixtures) error {
+	m.log.add("setup-module")
+	fixtures.Add("module", func(ctx context.Context) error {
+		m.log.add("release-module")
+		return nil
+	})
+	return m.setupModule
+}
+
+func (m *hookModule) TeardownModule(ctx context.Context, h agent.Harness) error {
+	m.log.add("teardown-module")
+	return nil
+}
+
+func (m *hookModule) SetupTest(ctx context.Context, h agent.Harness, test TestRef, fixtures *Fixtures) error {
+	m.log.add("setup-test " + test.ID)
+	if m.setupTest != nil {
+		return m.setupTest(test)
+	}
+	return nil
+}
+
+func (m *hookModule) TeardownTest(ctx context.Context, h agent.Harness, test TestRef) error {
+	m.log.add("teardown-test " + test.ID)
+	return nil
+}
+
+// cleanupCase returns a test case that registers a fixture and then runs fn
+func cleanupCase(id string, log *callLog, fn func(ctx context.Context) TestResult) TestCase {
+	return TestCase{
+		ID:   id,
+		Name: "Test " + id,
+		Run: func(ctx context.Context, h agent.Harness) TestResult {
+			Cleanup(ctx, id, func(ctx context.Context) error {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				log.add("release " + id)
+				return nil
+			})
+			return fn(ctx)
+		},
+	}
+}
+
+func newHookModule(name string, log *callLog, cases ...TestCase) *hookModule {
+	return &hookModule{caseModule: *newCaseModule(name, cases...), log: log}
+}
+
+func TestRun_HooksAndFixtureOrder(t *testing.T) {
+	log := &callLog{}
+	pass := func(ctx context.Context) TestResult { return NewPassResult("ok", "", CategorySDK, 0, "ok") }
+	module := newHookModule("m", log, cleanupCase("a", log, pass), cleanupCase("b", log, pass))
+
+	r := NewRunner(newStubHarness(), time.Minute, time.Second)
+	if err := r.RegisterModule(module); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Run(context.Background()); err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+
+	// Teardown hooks run before the fixtures of their scope are released
+	want := []string{
+		"setup-suite",
+		"setup-module",
+		"setup-test m/a", "teardown-test m/a", "release a",
+		"setup-test m/b", "teardown-test m/b", "release b",
+		"teardown-module", "release-module",
+		"teardown-suite", "release-suite",
+	}
+	if got := log.get(); !slices.Equal(got, want) {
+		t.Errorf("calls = %v, want %v", got, want)
+	}
+	if module.suiteSetups != 1 {
+		t.Errorf("SetupSuite called %d times, want 1", module.suiteSetups)
+	}
+}
+
+func TestFixtures_ReleaseOrder(t *testing.T) {
+	log := &callLog{}
+	f := newFixtures(ScopeModule, newStubHarness().Logger())
+
+	f.Add("first", func(ctx context.Context) error { log.add("first"); return nil })
+	f.Add("panics", func(ctx context.Context) error { panic("boom") })
+	f.Add("fails", func(ctx context.Context) error { return errors.New("failed") })
+	f.Add("last", func(ctx context.Context) error { log.add("last"); return nil })
+
+	err := f.release(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "release fails: failed") || !strings.Contains(err.Error(), "release panics: panic: boom") {
+		t.Errorf("release() error = %v, want failures of both fixtures", err)
+	}
+	if got, want := log.get(), []string{"last", "first"}; !slices.Equal(got, want) {
+		t.Errorf("released = %v, want %v", got, want)
+	}
+
+	// Resources acquired after the scope ended are released immediately
+	f.Add("late", func(ctx context.Context) error { log.add("late"); return nil })
+	if got := log.get(); got[len(got)-1] != "late" {
+		t.Errorf("late fixture not released immediately: %v", got)
+	}
+}
+
+func TestRun_FixturesReleasedOnPanicAndTimeout(t *testing.T) {
+	log := &callLog{}
+	module := newHookModule("m", log,
+		cleanupCase("panics", log, func(ctx context.Context) TestResult { panic("boom") }),
+		cleanupCase("hangs", log, func(ctx context.Context) TestResult {
+			<-ctx.Done()
+			return NewErrorResult("Test hangs", "", CategorySDK, 0, ctx.Err())
+		}),
+	)
+
+	r := NewRunner(newStubHarness(), 100*time.Millisecond, time.Second)
+	r.SetShutdownGrace(time.Second)
+	if err := r.RegisterModule(module); err != nil {
+		t.Fatal(err)
+	}
+	r.Run(context.Background())
+
+	got := log.get()
+	for _, want := range []string{"release panics", "release hangs", "teardown-module", "release-module", "teardown-suite", "release-suite"} {
+		if !slices.Contains(got, want) {
+			t.Errorf("calls = %v, missing %q", got, want)
+		}
+	}
+}
+
+func TestRun_ModuleSetupFailure(t *testing.T) {
+	var ran []string
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus []TestStatus
+	}{
+		{
+			name:       "skip",
+			err:        Skip("service unavailable"),
+			wantStatus: []TestStatus{TestStatusSkip, TestStatusSkip},
+		},
+		{
+			name:       "error",
+			err:        errors.New("connection refused"),
+			wantStatus: []TestStatus{TestStatusError, TestStatusSkip, TestStatusSkip},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ran = nil
+			log := &callLog{}
+			module := newHookModule("m", log,
+				recordingCase("a", TestStatusPass, &ran),
+				recordingCase("b", TestStatusPass, &ran),
+			)
+			module.setupModule = tt.err
+
+			r := NewRunner(newStubHarness(), time.Minute, time.Second)
+			if err := r.RegisterModule(module); err != nil {
+				t.Fatal(err)
+			}
+			suite, err := r.Run(context.Background())
+			if err != nil {
+				t.Fatalf("Run() unexpected error: %v", err)
+			}
+
+			if len(ran) != 0 {
+				t.Errorf("tests ran after failed setup: %v", ran)
+			}
+			var statuses []TestStatus
+			for _, result := range suite.Results {
+				statuses = append(statuses, result.Status)
+			}
+			if !slices.Equal(statuses, tt.wantStatus) {
+				t.Errorf("statuses = %v, want %v", statuses, tt.wantStatus)
+			}
+			if !slices.Contains(log.get(), "release-module") {
+				t.Error("module fixtures not released after failed setup")
+			}
+		})
+	}
+}
+
+func TestRun_TestSetupPerAttempt(t *testing.T) {
+	log := &callLog{}
+	attempts := 0
+	flaky := cleanupCase("flaky", log, func(ctx context.Context) TestResult {
+		attempts++
+		if attempts == 1 {
+			return NewFailResult("Test flaky", "", CategorySDK, 0, "failed", errors.New("failed"))
+		}
+		return NewPassResult("Test flaky", "", CategorySDK, 0, "ok")
+	})
+	flaky.Retry = &RetryPolicy{MaxAttempts: 2}
+
+	var ran []string
+	module := newHookModule("m", log, flaky, recordingCase("gated", TestStatusPass, &ran))
+	module.setupTest = func(test TestRef) error {
+		if test.ID == "m/gated" {
+			return Skip("dependency unavailable")
+		}
+		return nil
+	}
+
+	r := NewRunner(newStubHarness(), time.Minute, time.Second)
+	if err := r.RegisterModule(module); err != nil {
+		t.Fatal(err)
+	}
+	suite, err := r.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+
+	got := log.get()
+	if n := strings.Count(strings.Join(got, ","), "release flaky"); n != 2 {
+		t.Errorf("test fixture released %d times, want once per attempt: %v", n, got)
+	}
+	if len(ran) != 0 {
+		t.Errorf("gated test ran despite skipped setup")
+	}
+	gated := suite.Results[1]
+	if gated.Status != TestStatusSkip || gated.Message != "dependency unavailable" {
+		t.Errorf("gated result = %s %q, want skip with setup reason", gated.Status, gated.Message)
+	}
+}
@@ -0,0 +1,105 @@
+package runner
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DependentModule is implemented by test modules that must run after other modules.
+// Modules that do not implement it are considered independent and may run concurrently
+// with any other module.
+type DependentModule interface {
+	// DependsOn returns the names of modules that must complete before this module starts
+	DependsOn() []string
+}
+
+// moduleDependencies returns the declared dependencies of a module (nil if none)
+func moduleDependencies(module TestModule) []string {
+	if dm, ok := module.(DependentModule); ok {
+		return dm.DependsOn()
+	}
+	return nil
+}
+
+// findCycle searches the dependency graph formed by the given modules for a cycle.
+// Dependencies on modules that are not in the set are ignored, since they cannot
+// participate in a cycle until they are registered. Returns the module names that
+// form the cycle (first name repeated at the end), or nil if the graph is acyclic.
+func findCycle(modules []TestModule) []string {
+	byName := make(map[string]TestModule, len(modules))
+	for _, module := range modules {
+		byName[module.Name()] = module
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(modules))
+	stack := []string{}
+
+	var visit func(name string) []string
+	visit = func(name string) []string {
+		state[name] = visiting
+		stack = append(stack, name)
+
+		for _, dep := range moduleDependencies(byName[name]) {
+			if _, ok := byName[dep]; !ok {
+				continue
+			}
+			switch state[dep] {
+			case visiting:
+				// Found a back edge - extract the cycle from the stack
+				for i, n := range stack {
+					if n == dep {
+						cycle := append([]string{}, stack[i:]...)
+						return append(cycle, dep)
+					}
+				}
+			case unvisited:
+				if cycle := visit(dep); cycle != nil {
+					return cycle
+				}
+			}
+		}
+
+		stack = stack[:len(stack)-1]
+		state[name] = visited
+		return nil
+	}
+
+	for _, module := range modules {
+		if state[module.Name()] == unvisited {
+			if cycle := visit(module.Name()); cycle != nil {
+				return cycle
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateRegistration checks that adding a module keeps the module graph valid:
+// names must be unique and dependencies must not form a cycle
+func validateRegistration(existing []TestModule, module TestModule) error {
+	for _, m := range existing {
+		if m.Name() == module.Name() {
+			return fmt.Errorf("module already registered: %s", module.Name())
+		}
+	}
+
+	for _, dep := range moduleDependencies(module) {
+		if dep == module.Name() {
+			return fmt.Errorf("module %s depends on itself", module.Name())
+		}
+	}
+
+	// Search from the new module first so the reported cycle starts with it
+	candidate := append([]TestModule{module}, existing...)
+	if cycle := findCycle(candidate); cycle != nil {
+		return fmt.Errorf("dependency cycle detected: %s", strings.Join(cycle, " -> "))
+	}
+
+	return nil
+}
@@ -0,0 +1,206 @@
+package runner
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// junitSuiteName is the name of the top-level testsuites element
+const junitSuiteName = "debug-agent"
+
+// junitTestSuites is the root element of a JUnit XML report
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+// junitTestSuite holds the test cases of one module
+type junitTestSuite struct {
+	Name       string          `xml:"name,attr"`
+	Tests      int             `xml:"tests,attr"`
+	Failures   int             `xml:"failures,attr"`
+	Errors     int             `xml:"errors,attr"`
+	Skipped    int             `xml:"skipped,attr"`
+	Time       string          `xml:"time,attr"`
+	Timestamp  string          `xml:"timestamp,attr,omitempty"`
+	Properties []junitProperty `xml:"properties>property,omitempty"`
+	TestCases  []junitTestCase `xml:"testcase"`
+
+	duration time.Duration
+}
+
+// junitTestCase is a single test result
+type junitTestCase struct {
+	Name       string          `xml:"name,attr"`
+	ClassName  string          `xml:"classname,attr"`
+	Time       string          `xml:"time,attr"`
+	Properties []junitProperty `xml:"properties>property,omitempty"`
+	Failure    *junitProblem   `xml:"failure,omitempty"`
+	Error      *junitProblem   `xml:"error,omitempty"`
+	Skipped    *junitSkipped   `xml:"skipped,omitempty"`
+	Flaky      []junitProblem  `xml:"flakyFailure,omitempty"`
+	SystemOut  string          `xml:"system-out,omitempty"`
+}
+
+// junitProperty is a name/value pair attached to a suite or test case
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+// junitProblem describes a failure or error
+type junitProblem struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Body    string `xml:",chardata"`
+}
+
+// junitSkipped marks a skipped test case
+type junitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// JUnitXML renders the suite as a JUnit XML report with one testsuite per module
+// and one testcase per result. Flaky tests are reported as passing, with their
+// unsuccessful attempts as flakyFailure elements.
+func (sr *SuiteResult) JUnitXML() ([]byte, error) {
+	root := junitTestSuites{
+		Name: junitSuiteName,
+		Time: junitSeconds(sr.Duration()),
+	}
+
+	index := make(map[string]int)
+	for _, result := range sr.Results {
+		module := resultModule(result)
+		i, ok := index[module]
+		if !ok {
+			i = len(root.Suites)
+			index[module] = i
+			root.Suites = append(root.Suites, junitTestSuite{
+				Name:       module,
+				Properties: []junitProperty{{Name: "category", Value: string(result.Category)}},
+			})
+			if sr.Shuffled {
+				root.Suites[i].Properties = append(root.Suites[i].Properties,
+					junitProperty{Name: "shuffle_seed", Value: strconv.FormatInt(sr.ShuffleSeed, 10)})
+			}
+			if !sr.StartTime.IsZero() {
+				root.Suites[i].Timestamp = sr.StartTime.UTC().Format(time.RFC3339)
+			}
+		}
+
+		suite := &root.Suites[i]
+		suite.TestCases = append(suite.TestCases, newJUnitTestCase(module, result))
+		suite.Tests++
+		suite.duration += result.Duration
+
+		switch result.Status {
+		case TestStatusFail:
+			suite.Failures++
+		case TestStatusError:
+			suite.Errors++
+		case TestStatusSkip, TestStatusNotImplemented:
+			suite.Skipped++
+		}
+	}
+
+	for i := range root.Suites {
+		suite := &root.Suites[i]
+		suite.Time = junitSeconds(suite.duration)
+		root.Tests += suite.Tests
+		root.Failures += suite.Failures
+		root.Errors += suite.Errors
+		root.Skipped += suite.Skipped
+	}
+
+	body, err := xml.MarshalIndent(root, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal JUnit report: %w", err)
+	}
+	return append([]byte(xml.Header), body...), nil
+}
+
+// newJUnitTestCase maps a test result to a JUnit testcase
+func newJUnitTestCase(module string, result TestResult) junitTestCase {
+	tc := junitTestCase{
+		Name:      result.TestName,
+		ClassName: string(result.Category) + "." + module,
+		Time:      junitSeconds(result.Duration),
+	}
+	if result.RequirementID != "" {
+		tc.Properties = append(tc.Properties, junitProperty{Name: "requirement_id", Value: result.RequirementID})
+	}
+	if result.TestID != "" {
+		tc.Properties = append(tc.Properties, junitProperty{Name: "test_id", Value: result.TestID})
+	}
+
+	problem := &junitProblem{Message: result.Message, Type: string(result.Status)}
+	if testErr := AsTestError(result.Error); testErr != nil {
+		problem.Type = string(testErr.Kind)
+		problem.Body = testErr.Message
+		if testErr.Stack != "" {
+			problem.Body += "\n\n" + testErr.Stack
+		}
+	}
+
+	switch result.Status {
+	case TestStatusFail:
+		tc.Failure = problem
+	case TestStatusError:
+		tc.Error = problem
+	case TestStatusSkip:
+		tc.Skipped = &junitSkipped{Message: result.Message}
+	case TestStatusNotImplemented:
+		// JUnit has no such outcome; report a skip marked so dashboards can tell
+		tc.Skipped = &junitSkipped{Message: "not implemented: " + result.Message}
+		tc.Properties = append(tc.Properties, junitProperty{Name: "status", Value: string(result.Status)})
+	case TestStatusFlaky:
+		for _, attempt := range result.Attempts {
+			if attempt.Status.Succeeded() {
+				continue
+			}
+			tc.Flaky = append(tc.Flaky, junitProblem{
+				Message: fmt.Sprintf("attempt %d: %s", attempt.Number, attempt.Message),
+				Type:    string(attempt.Status),
+				Body:    attempt.Error,
+			})
+		}
+	}
+
+	if len(result.Details) > 0 {
+		details, err := json.MarshalIndent(result.Details, "", "  ")
+		if err != nil {
+			details = []byte(fmt.Sprintf("%v", result.Details))
+		}
+		tc.SystemOut = string(details)
+	}
+
+	return tc
+}
+
+// resultModule returns the name of the module that produced a result,
+// derived from its test ID and falling back to the category
+func resultModule(result TestResult) string {
+	if module, _, ok := strings.Cut(result.TestID, "/"); ok {
+		return module
+	}
+	if result.TestID != "" {
+		return result.TestID
+	}
+	return string(result.Category)
+}
+
+// junitSeconds formats a duration as JUnit seconds
+func junitSeconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
@@ -0,0 +1,118 @@
+package runner
+
+import (
+	"encoding/xml"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSuiteResult_JUnitXML(t *testing.T) {
+	suite := NewSuiteResult()
+
+	pass := NewPassResult("Parse Subnet", "NR-1", CategorySDK, 1500*time.Millisecond, "ok")
+	pass.TestID = "network-recon/parse-subnet"
+	pass.Details["subnet"] = "10.0.0.0/24"
+
+	fail := NewFailResult("Ping Sweep", "NR-2", CategorySDK, time.Second, "no hosts", errors.New("timeout"))
+	fail.TestID = "network-recon/ping-sweep"
+
+	skip := NewSkipResult("GraphRAG Storage", "NR-5", CategorySDK, "GraphRAG unavailable")
+	skip.TestID = "network-recon/graphrag-storage"
+
+	flaky := NewPassResult("LLM Analysis", "NR-6", CategorySDK, time.Second, "ok")
+	flaky.TestID = "network-recon/llm-analysis"
+	flaky.Status = TestStatusFlaky
+	flaky.Attempts = []Attempt{
+		{Number: 1, Status: TestStatusError, Message: "rate limited", Error: "429"},
+		{Number: 2, Status: TestStatusPass, Message: "ok"},
+	}
+
+	moduleErr := NewErrorResult("framework-comprehensive", "", CategoryFramework, 0, errors.New("module panicked"))
+	moduleErr.TestID = "framework-comprehensive"
+
+	suite.AddResults([]TestResult{pass, fail, skip, flaky, moduleErr})
+	suite.Finalize()
+
+	data, err := suite.JUnitXML()
+	if err != nil {
+		t.Fatalf("JUnitXML() unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(string(data), xml.Header) {
+		t.Error("JUnitXML() missing XML header")
+	}
+
+	var report junitTestSuites
+	if err := xml.Unmarshal(data, &report); err != nil {
+		t.Fatalf("JUnitXML() produced invalid XML: %v\n%s", err, data)
+	}
+
+	if report.Tests != 5 || report.Failures != 1 || report.Errors != 1 || report.Skipped != 1 {
+		t.Errorf("totals = tests %d, failures %d, errors %d, skipped %d; want 5, 1, 1, 1",
+			report.Tests, report.Failures, report.Errors, report.Skipped)
+	}
+	if len(report.Suites) != 2 {
+		t.Fatalf("got %d testsuites, want one per module (2)", len(report.Suites))
+	}
+
+	recon := report.Suites[0]
+	if recon.Name != "network-recon" || recon.Tests != 4 || recon.Time != "3.500" {
+		t.Errorf("testsuite = %s with %d tests in %ss, want network-recon with 4 tests in 3.500s",
+			recon.Name, recon.Tests, recon.Time)
+	}
+
+	tc := recon.TestCases[0]
+	if tc.ClassName != "sdk.network-recon" || tc.Time != "1.500" {
+		t.Errorf("testcase classname/time = %s/%s", tc.ClassName, tc.Time)
+	}
+	if len(tc.Properties) == 0 || tc.Properties[0] != (junitProperty{Name: "requirement_id", Value: "NR-1"}) {
+		t.Errorf("testcase properties = %v, want requirement_id first", tc.Properties)
+	}
+	if !strings.Contains(tc.SystemOut, `"subnet": "10.0.0.0/24"`) {
+		t.Errorf("system-out = %q, want details", tc.SystemOut)
+	}
+
+	if f := recon.TestCases[1].Failure; f == nil || f.Message != "no hosts" || f.Body != "timeout" {
+		t.Errorf("failure = %+v, want message and error", f)
+	}
+	if s := recon.TestCases[2].Skipped; s == nil || s.Message != "GraphRAG unavailable" {
+		t.Errorf("skipped = %+v, want skip reason", s)
+	}
+	if got := recon.TestCases[3]; got.Failure != nil || len(got.Flaky) != 1 || got.Flaky[0].Body != "429" {
+		t.Errorf("flaky testcase = %+v, want pass with one flakyFailure", got)
+	}
+	if e := report.Suites[1].TestCases[0].Error; e == nil || e.Body != "module panicked" {
+		t.Errorf("error = %+v, want module error", e)
+	}
+}
+
+func TestSuiteResult_JUnitXML_NotImplemented(t *testing.T) {
+	suite := NewSuiteResult()
+	pending := NewNotImplementedResult("Daemon Service", "17", CategoryFramework, "requires running Gibson daemon")
+	pending.TestID = "comprehensive-framework/daemon-service"
+	suite.AddResult(pending)
+	suite.Finalize()
+
+	data, err := suite.JUnitXML()
+	if err != nil {
+		t.Fatalf("JUnitXML() unexpected error: %v", err)
+	}
+
+	var report junitTestSuites
+	if err := xml.Unmarshal(data, &report); err != nil {
+		t.Fatalf("JUnitXML() produced invalid XML: %v\n%s", err, data)
+	}
+	if report.Skipped != 1 {
+		t.Errorf("skipped = %d, want not implemented tests reported as skipped", report.Skipped)
+	}
+
+	tc := report.Suites[0].TestCases[0]
+	if tc.Skipped == nil || tc.Skipped.Message != "not implemented: requires running Gibson daemon" {
+		t.Errorf("skipped = %+v, want not implemented message", tc.Skipped)
+	}
+	if !slices.Contains(tc.Properties, junitProperty{Name: "status", Value: "not_implemented"}) {
+		t.Errorf("properties = %v, want status not_implemented", tc.Properties)
+	}
+}
@@ -0,0 +1,157 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/zero-day-ai/sdk/memory"
+)
+
+// DefaultProgressKey is the working memory key under which suite progress is published
+const DefaultProgressKey = "debug_agent_progress"
+
+// progressWriteTimeout bounds each working memory update
+const progressWriteTimeout = 5 * time.Second
+
+// LogObserver writes suite progress to a structured logger
+type LogObserver struct {
+	logger *slog.Logger
+}
+
+// NewLogObserver creates an observer that logs progress events
+func NewLogObserver(logger *slog.Logger) *LogObserver {
+	return &LogObserver{logger: logger}
+}
+
+// Observe implements Observer
+func (o *LogObserver) Observe(ctx context.Context, event Event) {
+	progress := event.Progress
+
+	switch event.Type {
+	case EventSuiteStarted:
+		o.logger.Info("Suite started",
+			"modules", progress.ModulesTotal,
+			"tests", progress.TestsTotal,
+		)
+
+	case EventModuleStarted:
+		o.logger.Info("Module started",
+			"module", event.Module,
+			"category", event.Category,
+			"modules_completed", fmt.Sprintf("%d/%d", progress.ModulesCompleted, progress.ModulesTotal),
+		)
+
+	case EventTestStarted:
+		o.logger.Debug("Test started",
+			"module", event.Module,
+			"test_id", event.TestID,
+			"test", event.TestName,
+		)
+
+	case EventTestFinished:
+		level := slog.LevelInfo
+		if event.Status == TestStatusFail || event.Status == TestStatusError {
+			level = slog.LevelWarn
+		}
+		attrs := []any{
+			"module", event.Module,
+			"test_id", event.TestID,
+			"test", event.TestName,
+			"status", event.Status,
+			"tests_completed", fmt.Sprintf("%d/%d", progress.TestsCompleted, progress.TestsTotal),
+			"failed", progress.Failed,
+		}
+		if event.Result != nil {
+			attrs = append(attrs, "duration", event.Result.Duration)
+		}
+		o.logger.Log(ctx, level, "Test finished", attrs...)
+
+	case EventModuleFinished:
+		o.logger.Info("Module finished",
+			"module", event.Module,
+			"modules_completed", fmt.Sprintf("%d/%d", progress.ModulesCompleted, progress.ModulesTotal),
+		)
+
+	case EventSuiteFinished:
+		o.logger.Info("Suite finished",
+			"status", event.Status,
+			"tests_completed", progress.TestsCompleted,
+			"failed", progress.Failed,
+		)
+	}
+}
+
+// MemoryObserver publishes a progress snapshot to working memory so that other
+// agents in the mission can follow a running suite
+type MemoryObserver struct {
+	working memory.WorkingMemory
+	key     string
+	logger  *slog.Logger
+
+	running    []string
+	lastTest   string
+	lastStatus TestStatus
+	startedAt  time.Time
+}
+
+// NewMemoryObserver creates an observer that stores progress under key in working memory
+// Write failures are logged and otherwise ignored so they never affect the suite
+func NewMemoryObserver(working memory.WorkingMemory, key string, logger *slog.Logger) *MemoryObserver {
+	if key == "" {
+		key = DefaultProgressKey
+	}
+	return &MemoryObserver{working: working, key: key, logger: logger}
+}
+
+// Observe implements Observer
+func (o *MemoryObserver) Observe(ctx context.Context, event Event) {
+	status := "running"
+
+	switch event.Type {
+	case EventSuiteStarted:
+		o.running = nil
+		o.lastTest, o.lastStatus = "", ""
+		o.startedAt = event.Time
+	case EventModuleStarted:
+		o.running = append(o.running, event.Module)
+	case EventModuleFinished:
+		o.running = slices.DeleteFunc(o.running, func(name string) bool { return name == event.Module })
+	case EventTestFinished:
+		o.lastTest, o.lastStatus = event.TestID, event.Status
+	case EventSuiteFinished:
+		o.running = nil
+		status = string(event.Status)
+	case EventTestStarted:
+		// Test starts are too frequent to be worth a memory write
+		return
+	}
+
+	snapshot := map[string]any{
+		"status":            status,
+		"started_at":        o.startedAt,
+		"updated_at":        event.Time,
+		"modules_total":     event.Progress.ModulesTotal,
+		"modules_completed": event.Progress.ModulesCompleted,
+		"tests_total":       event.Progress.TestsTotal,
+		"tests_completed":   event.Progress.TestsCompleted,
+		"failed":            event.Progress.Failed,
+		"running_modules":   slices.Clone(o.running),
+		"last_test":         o.lastTest,
+		"last_status":       o.lastStatus,
+	}
+
+	// Publish even when the suite context has expired so the final state is visible
+	writeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), progressWriteTimeout)
+	defer cancel()
+
+	if err := o.working.Set(writeCtx, o.key, snapshot); err != nil && o.logger != nil {
+		o.logger.Warn("Failed to publish progress to working memory",
+			"key", o.key,
+			"event", event.Type,
+			"error", err,
+		)
+	}
+}
@@ -0,0 +1,243 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/zero-day-ai/sdk/agent"
+)
+
+// planTimeout bounds the precondition checks made while planning
+const planTimeout = 30 * time.Second
+
+// PlanAction is what a dry run predicts will happen to a test
+type PlanAction string
+
+const (
+	// PlanRun indicates the test is expected to run
+	PlanRun PlanAction = "run"
+
+	// PlanSkip indicates the test will be skipped
+	PlanSkip PlanAction = "skip"
+
+	// PlanFail indicates the test will run but cannot pass (e.g. a required tool is missing)
+	PlanFail PlanAction = "fail"
+)
+
+// Planner is implemented by test modules that can predict, without side effects,
+// what a test would do. PlanTest must not call tools or LLMs; it returns a short
+// description of the work the test would perform, a SkipError (see Skip) when the
+// test would be skipped, or another error when the test would fail.
+type Planner interface {
+	PlanTest(ctx context.Context, h agent.Harness, test TestRef) (string, error)
+}
+
+// PlanCheck is the outcome of one environment precondition check
+type PlanCheck struct {
+	Name    string `json:"name"`
+	OK      bool   `json:"ok"`
+	Message string `json:"message"`
+}
+
+// PlannedTest describes what a dry run predicts for one test
+type PlannedTest struct {
+	ID            string     `json:"id"`
+	Module        string     `json:"module"`
+	Name          string     `json:"name"`
+	RequirementID string     `json:"requirement_id,omitempty"`
+	Category      Category   `json:"category"`
+	Tags          []string   `json:"tags,omitempty"`
+	Tools         []string   `json:"tools,omitempty"`
+	Plugins       []string   `json:"plugins,omitempty"`
+	Action        PlanAction `json:"action"`
+	Reason        string     `json:"reason,omitempty"`
+	Description   string     `json:"description,omitempty"`
+}
+
+// Plan is the result of a dry run: the environment checks and the predicted
+// outcome of every registered test
+type Plan struct {
+	CreatedAt time.Time     `json:"created_at"`
+	Checks    []PlanCheck   `json:"checks"`
+	Tests     []PlannedTest `json:"tests"`
+}
+
+// Count returns the number of planned tests with the given action
+func (p *Plan) Count(action PlanAction) int {
+	n := 0
+	for _, test := range p.Tests {
+		if test.Action == action {
+			n++
+		}
+	}
+	return n
+}
+
+// planEnvironment holds what the harness reported during precondition checks
+type planEnvironment struct {
+	// tools and plugins are nil when they could not be listed
+	tools   map[string]bool
+	plugins map[string]bool
+}
+
+// Plan resolves the selection policy and checks preconditions without executing
+// any test, module hook, tool or LLM call. Environment checks list the available
+// tools and plugins and query GraphRAG health and the mission context; modules
+// implementing Planner predict skips for their own tests.
+func (r *Runner) Plan(ctx context.Context) (*Plan, error) {
+	ctx, cancel := context.WithTimeout(ctx, planTimeout)
+	defer cancel()
+
+	plan := &Plan{CreatedAt: time.Now(), Tests: []PlannedTest{}}
+	env := r.checkEnvironment(ctx, plan)
+
+	for _, module := range r.modules {
+		moduleSelected, moduleReason := true, ""
+		if r.policy != nil {
+			moduleSelected, moduleReason = r.policy.SelectModule(module)
+		}
+
+		cases := moduleCases(module)
+		if cases == nil {
+			test := PlannedTest{
+				ID:            module.Name(),
+				Module:        module.Name(),
+				Name:          module.Name(),
+				RequirementID: module.RequirementID(),
+				Category:      module.Category(),
+				Action:        PlanRun,
+				Description:   module.Description(),
+			}
+			if !moduleSelected {
+				test.Action, test.Reason = PlanSkip, moduleReason
+			}
+			plan.Tests = append(plan.Tests, test)
+			continue
+		}
+
+		c := caseRunner{module: module, policy: r.policy}
+		selected := c.selectCases(cases)
+		actions := make(map[string]PlanAction, len(cases))
+		for _, tc := range cases {
+			test := r.planCase(ctx, c, tc, env, selected, actions)
+			if !moduleSelected {
+				test.Action, test.Reason, test.Description = PlanSkip, moduleReason, ""
+			}
+			actions[tc.ID] = test.Action
+			plan.Tests = append(plan.Tests, test)
+		}
+	}
+
+	r.logger.Info("Execution plan created",
+		"run", plan.Count(PlanRun),
+		"skip", plan.Count(PlanSkip),
+		"fail", plan.Count(PlanFail),
+	)
+
+	return plan, nil
+}
+
+// checkEnvironment records the environment precondition checks in the plan
+func (r *Runner) checkEnvironment(ctx context.Context, plan *Plan) planEnvironment {
+	var env planEnvironment
+
+	if tools, err := r.harness.ListTools(ctx); err != nil {
+		plan.Checks = append(plan.Checks, PlanCheck{Name: "tools", Message: fmt.Sprintf("ListTools failed: %v", err)})
+	} else {
+		env.tools = make(map[string]bool, len(tools))
+		for _, t := range tools {
+			env.tools[t.Name] = true
+		}
+		plan.Checks = append(plan.Checks, PlanCheck{Name: "tools", OK: true, Message: fmt.Sprintf("%d tools available", len(tools))})
+	}
+
+	if plugins, err := r.harness.ListPlugins(ctx); err != nil {
+		plan.Checks = append(plan.Checks, PlanCheck{Name: "plugins", Message: fmt.Sprintf("ListPlugins failed: %v", err)})
+	} else {
+		env.plugins = make(map[string]bool, len(plugins))
+		for _, p := range plugins {
+			env.plugins[p.Name] = true
+		}
+		plan.Checks = append(plan.Checks, PlanCheck{Name: "plugins", OK: true, Message: fmt.Sprintf("%d plugins available", len(plugins))})
+	}
+
+	health := r.harness.GraphRAGHealth(ctx)
+	graphCheck := PlanCheck{Name: "graphrag", OK: health.Status == "healthy", Message: health.Status}
+	if health.Message != "" {
+		graphCheck.Message += " - " + health.Message
+	}
+	plan.Checks = append(plan.Checks, graphCheck)
+
+	if mission := r.harness.Mission(); mission.ID != "" {
+		plan.Checks = append(plan.Checks, PlanCheck{Name: "mission", OK: true, Message: fmt.Sprintf("mission %s (%s)", mission.ID, mission.Name)})
+	} else {
+		plan.Checks = append(plan.Checks, PlanCheck{Name: "mission", Message: "no mission context"})
+	}
+
+	return env
+}
+
+// planCase predicts the outcome of one test case, mirroring the checks the
+// case runner makes: selection, prerequisites, then the test's own preconditions
+func (r *Runner) planCase(ctx context.Context, c caseRunner, tc TestCase, env planEnvironment,
+	selected map[string]string, actions map[string]PlanAction) PlannedTest {
+	ref := c.testRef(tc)
+	test := PlannedTest{
+		ID:            ref.ID,
+		Module:        c.module.Name(),
+		Name:          tc.Name,
+		RequirementID: tc.RequirementID,
+		Category:      c.module.Category(),
+		Tags:          tc.Tags,
+		Tools:         tc.Tools,
+		Plugins:       tc.Plugins,
+		Action:        PlanRun,
+	}
+
+	if reason, excluded := selected[tc.ID]; excluded {
+		test.Action, test.Reason = PlanSkip, reason
+		return test
+	}
+
+	for _, prereq := range tc.Prerequisites {
+		if action := actions[prereq]; action != PlanRun {
+			test.Action = PlanSkip
+			test.Reason = fmt.Sprintf("Prerequisite %s will not pass (%s)", QualifiedTestID(c.module.Name(), prereq), action)
+			return test
+		}
+	}
+
+	if planner, ok := c.module.(Planner); ok {
+		var description string
+		err := callSetup(ctx, func(ctx context.Context) error {
+			var err error
+			description, err = planner.PlanTest(ctx, r.harness, ref)
+			return err
+		})
+		test.Description = description
+		if reason, skipped := skipReason(err); skipped {
+			test.Action, test.Reason = PlanSkip, reason
+			return test
+		} else if err != nil {
+			test.Action, test.Reason = PlanFail, err.Error()
+			return test
+		}
+	}
+
+	if env.tools != nil {
+		if i := slices.IndexFunc(tc.Tools, func(name string) bool { return !env.tools[name] }); i >= 0 {
+			test.Action, test.Reason = PlanFail, fmt.Sprintf("Tool %s is not available", tc.Tools[i])
+			return test
+		}
+	}
+	if env.plugins != nil {
+		if i := slices.IndexFunc(tc.Plugins, func(name string) bool { return !env.plugins[name] }); i >= 0 {
+			test.Action, test.Reason = PlanFail, fmt.Sprintf("Plugin %s is not available", tc.Plugins[i])
+			return test
+		}
+	}
+
+	return test
+}
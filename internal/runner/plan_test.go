@@ -0,0 +1,118 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zero-day-ai/sdk/agent"
+	"github.com/zero-day-ai/sdk/plugin"
+	"github.com/zero-day-ai/sdk/tool"
+	"github.com/zero-day-ai/sdk/types"
+)
+
+// planHarness answers the precondition queries made by Plan
+type planHarness struct {
+	*stubHarness
+	tools   []string
+	health  string
+	mission string
+}
+
+func (p *planHarness) ListTools(ctx context.Context) ([]tool.Descriptor, error) {
+	descriptors := make([]tool.Descriptor, len(p.tools))
+	for i, name := range p.tools {
+		descriptors[i] = tool.Descriptor{Name: name}
+	}
+	return descriptors, nil
+}
+
+func (p *planHarness) ListPlugins(ctx context.Context) ([]plugin.Descriptor, error) {
+	return nil, errors.New("plugin registry unavailable")
+}
+
+func (p *planHarness) GraphRAGHealth(ctx context.Context) types.HealthStatus {
+	return types.HealthStatus{Status: p.health}
+}
+
+func (p *planHarness) Mission() types.MissionContext {
+	return types.MissionContext{ID: p.mission}
+}
+
+// plannerModule predicts skips for tests tagged "graphrag"
+type plannerModule struct {
+	*caseModule
+}
+
+func (p plannerModule) PlanTest(ctx context.Context, h agent.Harness, test TestRef) (string, error) {
+	if test.Name == "Test graph" && h.GraphRAGHealth(ctx).Status != "healthy" {
+		return "", Skip("GraphRAG unavailable")
+	}
+	return "would run " + test.Name, nil
+}
+
+func TestPlan(t *testing.T) {
+	var ran []string
+	scan := recordingCase("scan", TestStatusPass, &ran)
+	scan.Tools = []string{"ping", "nmap"}
+	graph := recordingCase("graph", TestStatusPass, &ran)
+	store := recordingCase("store", TestStatusPass, &ran, "graph")
+	excluded := recordingCase("excluded", TestStatusPass, &ran)
+
+	h := &planHarness{stubHarness: newStubHarness(), tools: []string{"ping"}, health: "unhealthy"}
+	r := NewRunner(h, time.Minute, time.Second)
+	r.SetSelectionPolicy(Selection{SkipTests: []string{"recon/excluded"}})
+	if err := r.RegisterModules(
+		plannerModule{newCaseModule("recon", scan, graph, store, excluded)},
+		newFakeModule("opaque"),
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	plan, err := r.Plan(context.Background())
+	if err != nil {
+		t.Fatalf("Plan() unexpected error: %v", err)
+	}
+	if len(ran) != 0 {
+		t.Errorf("Plan() executed tests: %v", ran)
+	}
+
+	want := []struct {
+		id     string
+		action PlanAction
+		reason string
+	}{
+		{"recon/scan", PlanFail, "Tool nmap is not available"},
+		{"recon/graph", PlanSkip, "GraphRAG unavailable"},
+		{"recon/store", PlanSkip, "Prerequisite recon/graph will not pass (skip)"},
+		{"recon/excluded", PlanSkip, "Test Test excluded skipped by configuration (skip_tests)"},
+		{"opaque", PlanRun, ""},
+	}
+	if len(plan.Tests) != len(want) {
+		t.Fatalf("Plan() has %d tests, want %d", len(plan.Tests), len(want))
+	}
+	for i, w := range want {
+		got := plan.Tests[i]
+		if got.ID != w.id || got.Action != w.action || got.Reason != w.reason {
+			t.Errorf("Tests[%d] = {%s %s %q}, want {%s %s %q}", i, got.ID, got.Action, got.Reason, w.id, w.action, w.reason)
+		}
+	}
+	if plan.Tests[0].Description != "would run Test scan" {
+		t.Errorf("Description = %q, want planner description", plan.Tests[0].Description)
+	}
+
+	checks := make(map[string]bool)
+	for _, check := range plan.Checks {
+		checks[check.Name] = check.OK
+	}
+	wantChecks := map[string]bool{"tools": true, "plugins": false, "graphrag": false, "mission": false}
+	for name, ok := range wantChecks {
+		if got, found := checks[name]; !found || got != ok {
+			t.Errorf("check %s = %v (found %v), want %v", name, got, found, ok)
+		}
+	}
+	if plan.Count(PlanSkip) != 3 {
+		t.Errorf("Count(skip) = %d, want 3", plan.Count(PlanSkip))
+	}
+}
@@ -20,8 +20,21 @@ const (
 
 	// TestStatusError indicates the test encountered an error during execution
 	TestStatusError TestStatus = "error"
+
+	// TestStatusFlaky indicates the test passed only after one or more failed attempts
+	TestStatusFlaky TestStatus = "flaky"
+
+	// TestStatusNotImplemented indicates the check does not exist yet, so its
+	// requirement is unverified. It neither passes nor fails the suite and is
+	// left out of the pass rate.
+	TestStatusNotImplemented TestStatus = "not_implemented"
 )
 
+// Succeeded reports whether the status counts as a successful test (pass or flaky)
+func (s TestStatus) Succeeded() bool {
+	return s == TestStatusPass || s == TestStatusFlaky
+}
+
 // Category represents whether a test is for SDK or Framework
 type Category string
 
@@ -35,6 +48,10 @@ const (
 
 // TestResult represents the outcome of a single test execution
 type TestResult struct {
+	// TestID is the qualified identifier of the test ("<module>/<id>"), or the
+	// module name for modules that do not provide test cases
+	TestID string
+
 	// TestName is the human-readable name of the test
 	TestName string
 
@@ -44,7 +61,7 @@ type TestResult struct {
 	// Category indicates if this is an SDK or Framework test
 	Category Category
 
-	// Status is the test outcome (pass, fail, skip, error)
+	// Status is the test outcome (pass, fail, skip, error, flaky, not_implemented)
 	Status TestStatus
 
 	// Duration is how long the test took to execute
@@ -59,6 +76,9 @@ type TestResult struct {
 	// Details contains additional context (inputs, outputs, etc.)
 	Details map[string]any
 
+	// Attempts records every attempt when the test was retried (nil if it ran once)
+	Attempts []Attempt
+
 	// Timestamp is when the test completed
 	Timestamp time.Time
 }
@@ -106,6 +126,20 @@ func NewSkipResult(testName, requirementID string, category Category, message st
 	}
 }
 
+// NewNotImplementedResult creates a TestResult for a check that does not exist
+// yet; message describes what the check would have to verify
+func NewNotImplementedResult(testName, requirementID string, category Category, message string) TestResult {
+	return TestResult{
+		TestName:      testName,
+		RequirementID: requirementID,
+		Category:      category,
+		Status:        TestStatusNotImplemented,
+		Message:       message,
+		Timestamp:     time.Now(),
+		Details:       make(map[string]any),
+	}
+}
+
 // NewErrorResult creates a TestResult for a test that encountered an error
 func NewErrorResult(testName, requirementID string, category Category, duration time.Duration, err error) TestResult {
 	return TestResult{
@@ -143,6 +177,12 @@ type CategorySummary struct {
 
 	// Errors is the number of tests with errors
 	Errors int
+
+	// Flaky is the number of tests that passed after a retry
+	Flaky int
+
+	// NotImplemented is the number of tests whose checks do not exist yet
+	NotImplemented int
 }
 
 // CalculateSummary computes a CategorySummary from test results
@@ -161,6 +201,10 @@ func CalculateSummary(results []TestResult) CategorySummary {
 			summary.Skipped++
 		case TestStatusError:
 			summary.Errors++
+		case TestStatusFlaky:
+			summary.Flaky++
+		case TestStatusNotImplemented:
+			summary.NotImplemented++
 		}
 	}
 
@@ -168,9 +212,12 @@ func CalculateSummary(results []TestResult) CategorySummary {
 }
 
 // PassRate returns the percentage of tests that passed (0.0 - 1.0)
+// Flaky tests count as passed since they eventually succeeded; tests that are
+// not implemented are left out, as they verify nothing
 func (cs CategorySummary) PassRate() float64 {
-	if cs.Total == 0 {
+	implemented := cs.Total - cs.NotImplemented
+	if implemented == 0 {
 		return 0
 	}
-	return float64(cs.Passed) / float64(cs.Total)
+	return float64(cs.Passed+cs.Flaky) / float64(implemented)
 }
@@ -0,0 +1,131 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// RetryPolicy controls how often a test case is re-attempted after an unsuccessful run.
+// A test that succeeds after at least one unsuccessful attempt is reported as flaky.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts including the first (values below 2 disable retries)
+	MaxAttempts int
+
+	// Backoff is the delay before the second attempt
+	Backoff time.Duration
+
+	// BackoffFactor multiplies the delay after every further attempt (values below 1 keep it constant)
+	BackoffFactor float64
+
+	// MaxBackoff caps the delay between attempts (0 means no cap)
+	MaxBackoff time.Duration
+
+	// RetryOn lists the statuses that trigger another attempt
+	// Defaults to fail and error when empty
+	RetryOn []TestStatus
+}
+
+// NoRetry is the retry policy that runs every test exactly once
+var NoRetry = RetryPolicy{MaxAttempts: 1}
+
+// Attempt records the outcome of a single attempt of a retried test
+type Attempt struct {
+	// Number is the 1-based attempt number
+	Number int
+
+	// Status is the outcome of this attempt
+	Status TestStatus
+
+	// Message is the result message of this attempt
+	Message string
+
+	// Error is the error text of this attempt (empty if none)
+	Error string
+
+	// ErrorKind classifies the error of this attempt (empty if none)
+	ErrorKind ErrorKind
+
+	// Duration is how long this attempt took
+	Duration time.Duration
+
+	// Timestamp is when this attempt completed
+	Timestamp time.Time
+}
+
+// newAttempt records a result as the given attempt
+func newAttempt(number int, result TestResult) Attempt {
+	attempt := Attempt{
+		Number:    number,
+		Status:    result.Status,
+		Message:   result.Message,
+		Duration:  result.Duration,
+		Timestamp: result.Timestamp,
+	}
+	if testErr := AsTestError(result.Error); testErr != nil {
+		attempt.Error = testErr.Message
+		attempt.ErrorKind = testErr.Kind
+	}
+	return attempt
+}
+
+// retryable reports whether a result with the given status should be re-attempted
+func (p RetryPolicy) retryable(status TestStatus) bool {
+	if len(p.RetryOn) == 0 {
+		return status == TestStatusFail || status == TestStatusError
+	}
+	return slices.Contains(p.RetryOn, status)
+}
+
+// delay returns the backoff before the given attempt (2 for the first retry)
+func (p RetryPolicy) delay(attempt int) time.Duration {
+	delay := p.Backoff
+	if p.BackoffFactor > 1 {
+		for i := 2; i < attempt; i++ {
+			delay = time.Duration(float64(delay) * p.BackoffFactor)
+		}
+	}
+	if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+	return delay
+}
+
+// runWithRetry executes a test attempt function according to the policy.
+// Every attempt is recorded on the final result when the test was retried; a test
+// that eventually passes is reported as flaky. Retries stop when the context ends.
+func runWithRetry(ctx context.Context, policy RetryPolicy, attempt func() TestResult) TestResult {
+	var attempts []Attempt
+	var result TestResult
+
+	for number := 1; ; number++ {
+		result = attempt()
+		attempts = append(attempts, newAttempt(number, result))
+
+		if number >= policy.MaxAttempts || !policy.retryable(result.Status) || ctx.Err() != nil {
+			break
+		}
+
+		timer := time.NewTimer(policy.delay(number + 1))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	if len(attempts) == 1 {
+		return result
+	}
+
+	result.Attempts = attempts
+	if result.Status == TestStatusPass {
+		result.Status = TestStatusFlaky
+		result.Message = fmt.Sprintf("%s (passed on attempt %d of %d)", result.Message, len(attempts), policy.MaxAttempts)
+	}
+	return result
+}
@@ -0,0 +1,147 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zero-day-ai/sdk/agent"
+)
+
+// sequenceCase returns a test case that reports the given statuses on successive attempts
+func sequenceCase(id string, retry *RetryPolicy, statuses ...TestStatus) (TestCase, *int) {
+	calls := 0
+	return TestCase{
+		ID:    id,
+		Name:  "Test " + id,
+		Retry: retry,
+		Run: func(ctx context.Context, h agent.Harness) TestResult {
+			status := statuses[min(calls, len(statuses)-1)]
+			calls++
+			switch status {
+			case TestStatusFail:
+				return NewFailResult("Test "+id, "", CategorySDK, 0, "transient failure", errors.New("boom"))
+			case TestStatusSkip:
+				return NewSkipResult("Test "+id, "", CategorySDK, "not available")
+			}
+			return NewPassResult("Test "+id, "", CategorySDK, 0, "ok")
+		},
+	}, &calls
+}
+
+func TestRun_RetryPolicy(t *testing.T) {
+	tests := []struct {
+		name         string
+		policy       RetryPolicy
+		statuses     []TestStatus
+		wantStatus   TestStatus
+		wantCalls    int
+		wantAttempts int
+	}{
+		{
+			name:         "passes after failure is flaky",
+			policy:       RetryPolicy{MaxAttempts: 3},
+			statuses:     []TestStatus{TestStatusFail, TestStatusPass},
+			wantStatus:   TestStatusFlaky,
+			wantCalls:    2,
+			wantAttempts: 2,
+		},
+		{
+			name:         "exhausted attempts keep failure",
+			policy:       RetryPolicy{MaxAttempts: 3},
+			statuses:     []TestStatus{TestStatusFail},
+			wantStatus:   TestStatusFail,
+			wantCalls:    3,
+			wantAttempts: 3,
+		},
+		{
+			name:         "first attempt pass is not retried",
+			policy:       RetryPolicy{MaxAttempts: 3},
+			statuses:     []TestStatus{TestStatusPass},
+			wantStatus:   TestStatusPass,
+			wantCalls:    1,
+			wantAttempts: 0,
+		},
+		{
+			name:         "skip is not retryable by default",
+			policy:       RetryPolicy{MaxAttempts: 3},
+			statuses:     []TestStatus{TestStatusSkip, TestStatusPass},
+			wantStatus:   TestStatusSkip,
+			wantCalls:    1,
+			wantAttempts: 0,
+		},
+		{
+			name:         "retry on skip when configured",
+			policy:       RetryPolicy{MaxAttempts: 2, RetryOn: []TestStatus{TestStatusSkip}},
+			statuses:     []TestStatus{TestStatusSkip, TestStatusPass},
+			wantStatus:   TestStatusFlaky,
+			wantCalls:    2,
+			wantAttempts: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc, calls := sequenceCase("a", &tt.policy, tt.statuses...)
+			r := NewRunner(newStubHarness(), time.Minute, time.Second)
+			if err := r.RegisterModule(newCaseModule("m", tc)); err != nil {
+				t.Fatalf("RegisterModule() unexpected error: %v", err)
+			}
+
+			suite, err := r.Run(context.Background())
+			if err != nil {
+				t.Fatalf("Run() unexpected error: %v", err)
+			}
+
+			result := suite.Results[0]
+			if result.Status != tt.wantStatus {
+				t.Errorf("Status = %s, want %s", result.Status, tt.wantStatus)
+			}
+			if *calls != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", *calls, tt.wantCalls)
+			}
+			if len(result.Attempts) != tt.wantAttempts {
+				t.Errorf("len(Attempts) = %d, want %d", len(result.Attempts), tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestRun_FlakyDoesNotFailSuite(t *testing.T) {
+	tc, _ := sequenceCase("a", nil, TestStatusFail, TestStatusPass)
+	r := NewRunner(newStubHarness(), time.Minute, time.Second)
+	r.SetRetryPolicy(RetryPolicy{MaxAttempts: 2})
+	if err := r.RegisterModule(newCaseModule("m", tc)); err != nil {
+		t.Fatalf("RegisterModule() unexpected error: %v", err)
+	}
+
+	suite, err := r.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+
+	if suite.OverallStatus != TestStatusPass {
+		t.Errorf("OverallStatus = %s, want pass", suite.OverallStatus)
+	}
+	if suite.TotalFlaky() != 1 {
+		t.Errorf("TotalFlaky() = %d, want 1", suite.TotalFlaky())
+	}
+	if suite.OverallPassRate() != 1 {
+		t.Errorf("OverallPassRate() = %v, want 1", suite.OverallPassRate())
+	}
+	if got := suite.Results[0].Attempts[0]; got.Status != TestStatusFail || got.Error != "boom" {
+		t.Errorf("Attempts[0] = %s/%q, want fail/boom", got.Status, got.Error)
+	}
+}
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	policy := RetryPolicy{Backoff: time.Second, BackoffFactor: 2, MaxBackoff: 3 * time.Second}
+
+	want := map[int]time.Duration{2: time.Second, 3: 2 * time.Second, 4: 3 * time.Second}
+	for attempt, delay := range want {
+		if got := policy.delay(attempt); got != delay {
+			t.Errorf("delay(%d) = %v, want %v", attempt, got, delay)
+		}
+	}
+}
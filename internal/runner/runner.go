@@ -2,6 +2,7 @@ package runner
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"runtime/debug"
@@ -38,63 +39,179 @@ type Runner struct {
 	logger      *slog.Logger
 	timeout     time.Duration
 	testTimeout time.Duration
+	concurrency int
+
+	// policy selects which modules and tests run (nil runs everything)
+	policy SelectionPolicy
+
+	// categoryTimeout bounds the time spent on each category (0 disables the limit)
+	categoryTimeout time.Duration
+
+	// shutdownGrace bounds how long the runner waits for a cancelled module to exit
+	shutdownGrace time.Duration
+
+	// retry is the default retry policy for test cases without their own
+	retry RetryPolicy
+
+	// failFast selects which remaining tests are aborted after a failure
+	failFast FailFastScope
+
+	// shuffle randomizes module and test order using shuffleSeed
+	shuffle     bool
+	shuffleSeed int64
+
+	// events publishes progress to registered observers
+	events *eventBus
 }
 
+// DefaultShutdownGrace is how long the runner waits for a cancelled module to return
+const DefaultShutdownGrace = 5 * time.Second
+
 // NewRunner creates a new test runner
+// Modules are executed sequentially unless a higher concurrency is set with SetConcurrency
 func NewRunner(harness agent.Harness, timeout, testTimeout time.Duration) *Runner {
+	logger := harness.Logger()
 	return &Runner{
-		modules:     []TestModule{},
-		harness:     harness,
-		logger:      harness.Logger(),
-		timeout:     timeout,
-		testTimeout: testTimeout,
+		modules:       []TestModule{},
+		harness:       harness,
+		logger:        logger,
+		timeout:       timeout,
+		testTimeout:   testTimeout,
+		concurrency:   1,
+		shutdownGrace: DefaultShutdownGrace,
+		retry:         NoRetry,
+		events: &eventBus{
+			onPanic: func(observer Observer, event Event, panicErr any) {
+				logger.Error("Observer panicked",
+					"observer", fmt.Sprintf("%T", observer),
+					"event", event.Type,
+					"panic", panicErr,
+				)
+			},
+		},
+	}
+}
+
+// AddObserver registers an observer for suite progress events
+func (r *Runner) AddObserver(observer Observer) {
+	r.events.add(observer)
+}
+
+// SetRetryPolicy sets the default retry policy for test cases
+// Test cases that declare their own policy (TestCase.Retry) keep it
+func (r *Runner) SetRetryPolicy(policy RetryPolicy) {
+	r.retry = policy
+}
+
+// SetShutdownGrace sets how long the runner waits for a timed-out or cancelled
+// module to return before abandoning it and reporting the results recorded so far
+func (r *Runner) SetShutdownGrace(grace time.Duration) {
+	r.shutdownGrace = grace
+}
+
+// SetConcurrency sets the maximum number of modules that may execute at the same time
+// Values below 1 are treated as 1 (sequential execution)
+func (r *Runner) SetConcurrency(n int) {
+	if n < 1 {
+		n = 1
 	}
+	r.concurrency = n
+}
+
+// SetSelectionPolicy sets the policy used to filter modules and individual tests
+// Tests rejected by the policy are reported as skipped with the policy's reason
+func (r *Runner) SetSelectionPolicy(policy SelectionPolicy) {
+	r.policy = policy
+}
+
+// SetFailFast aborts the remaining tests of the suite, category or module after
+// the first failed or errored test. Aborted tests are reported as skipped.
+func (r *Runner) SetFailFast(scope FailFastScope) {
+	r.failFast = scope
+}
+
+// SetShuffle runs modules and tests in a random order derived from seed, keeping
+// declared dependencies and prerequisites first. The same seed reproduces the same
+// order; modules start strictly in that order, so with a concurrency of 1 the whole
+// run is reproduced exactly.
+func (r *Runner) SetShuffle(seed int64) {
+	r.shuffle = true
+	r.shuffleSeed = seed
+}
+
+// SetCategoryTimeout sets the deadline applied to each test category
+// The clock for a category starts when its first module starts executing
+func (r *Runner) SetCategoryTimeout(timeout time.Duration) {
+	r.categoryTimeout = timeout
 }
 
 // RegisterModule adds a test module to the runner
-func (r *Runner) RegisterModule(module TestModule) {
+// Returns an error if a module with the same name is already registered, if the
+// module's declared dependencies would introduce a dependency cycle, or if its test
+// cases have duplicate IDs or unknown prerequisites
+func (r *Runner) RegisterModule(module TestModule) error {
+	if err := validateRegistration(r.modules, module); err != nil {
+		return err
+	}
+	if err := validateCases(module); err != nil {
+		return err
+	}
 	r.modules = append(r.modules, module)
+	return nil
 }
 
 // RegisterModules adds multiple test modules to the runner
-func (r *Runner) RegisterModules(modules ...TestModule) {
-	r.modules = append(r.modules, modules...)
+// Registration stops at the first module that fails validation
+func (r *Runner) RegisterModules(modules ...TestModule) error {
+	for _, module := range modules {
+		if err := r.RegisterModule(module); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // Run executes all registered test modules and returns aggregated results
 func (r *Runner) Run(ctx context.Context) (*SuiteResult, error) {
+	return r.execute(ctx, r.modules, r.policy)
+}
+
+// execute runs the given modules under a selection policy as one suite
+func (r *Runner) execute(ctx context.Context, modules []TestModule, policy SelectionPolicy) (*SuiteResult, error) {
 	r.logger.Info("Starting test suite execution",
-		"modules", len(r.modules),
+		"modules", len(modules),
 		"timeout", r.timeout,
+		"concurrency", r.concurrency,
 	)
 
 	suite := NewSuiteResult()
+	modules = r.shuffled(modules, suite)
 
 	// Create a context with timeout for the entire suite
 	suiteCtx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 
-	// Execute modules sequentially
-	// (Could be parallelized in the future, but sequential is safer for now)
-	for _, module := range r.modules {
-		// Check if suite context is still valid
-		select {
-		case <-suiteCtx.Done():
-			r.logger.Warn("Suite execution timed out or cancelled",
-				"executed_modules", len(suite.Results),
-				"total_modules", len(r.modules),
-			)
-			suite.Finalize()
-			return suite, fmt.Errorf("suite execution timed out or cancelled: %w", suiteCtx.Err())
-		default:
-		}
+	r.events.start(modules)
+	r.events.emit(ctx, Event{Type: EventSuiteStarted})
 
-		// Execute the module
-		results := r.runModule(suiteCtx, module)
+	// Execute modules, running independent modules concurrently
+	moduleResults, notStarted := r.runModules(suiteCtx, modules, policy)
+	for _, results := range moduleResults {
 		suite.AddResults(results)
 	}
 
+	if notStarted > 0 {
+		r.logger.Warn("Suite execution timed out or cancelled",
+			"executed_modules", len(modules)-notStarted,
+			"total_modules", len(modules),
+		)
+		suite.Finalize()
+		r.events.emit(ctx, Event{Type: EventSuiteFinished, Status: suite.OverallStatus, Suite: suite})
+		return suite, fmt.Errorf("suite execution timed out or cancelled: %w", suiteCtx.Err())
+	}
+
 	suite.Finalize()
+	r.events.emit(ctx, Event{Type: EventSuiteFinished, Status: suite.OverallStatus, Suite: suite})
 
 	r.logger.Info("Test suite execution completed",
 		"duration", suite.Duration(),
@@ -122,28 +239,27 @@ func (r *Runner) RunCategory(ctx context.Context, category Category) (*SuiteResu
 	categoryCtx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 
-	// Execute modules in the specified category
-	for _, module := range r.modules {
-		if module.Category() != category {
-			continue
-		}
+	modules := r.shuffled(r.GetModulesByCategory(category), suite)
+	r.events.start(modules)
+	r.events.emit(ctx, Event{Type: EventSuiteStarted, Category: category})
 
-		// Check if context is still valid
-		select {
-		case <-categoryCtx.Done():
-			r.logger.Warn("Category execution timed out or cancelled",
-				"category", category,
-			)
-			suite.Finalize()
-			return suite, fmt.Errorf("category execution timed out: %w", categoryCtx.Err())
-		default:
-		}
-
-		results := r.runModule(categoryCtx, module)
+	// Execute modules in the specified category
+	moduleResults, notStarted := r.runModules(categoryCtx, modules, r.policy)
+	for _, results := range moduleResults {
 		suite.AddResults(results)
 	}
 
+	if notStarted > 0 {
+		r.logger.Warn("Category execution timed out or cancelled",
+			"category", category,
+		)
+		suite.Finalize()
+		r.events.emit(ctx, Event{Type: EventSuiteFinished, Category: category, Status: suite.OverallStatus, Suite: suite})
+		return suite, fmt.Errorf("category execution timed out: %w", categoryCtx.Err())
+	}
+
 	suite.Finalize()
+	r.events.emit(ctx, Event{Type: EventSuiteFinished, Category: category, Status: suite.OverallStatus, Suite: suite})
 
 	r.logger.Info("Category execution completed",
 		"category", category,
@@ -175,12 +291,12 @@ func (r *Runner) RunSingle(ctx context.Context, moduleName string) ([]TestResult
 		return nil, fmt.Errorf("module not found: %s", moduleName)
 	}
 
-	// Create a context with timeout
-	moduleCtx, cancel := context.WithTimeout(ctx, r.testTimeout)
+	// Create a context with timeout (individual tests are bounded by the test timeout)
+	moduleCtx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 
 	// Execute the module
-	results := r.runModule(moduleCtx, targetModule)
+	results := r.runModule(moduleCtx, targetModule, r.policy, newFailFast(r.failFast, r.logger))
 
 	r.logger.Info("Single module execution completed",
 		"module", moduleName,
@@ -190,8 +306,333 @@ func (r *Runner) RunSingle(ctx context.Context, moduleName string) ([]TestResult
 	return results, nil
 }
 
-// runModule executes a single test module with panic recovery
-func (r *Runner) runModule(ctx context.Context, module TestModule) []TestResult {
+// shuffled returns the modules in shuffled order when shuffling is enabled and
+// records the seed in the suite result
+func (r *Runner) shuffled(modules []TestModule, suite *SuiteResult) []TestModule {
+	if !r.shuffle {
+		return modules
+	}
+
+	suite.Shuffled = true
+	suite.ShuffleSeed = r.shuffleSeed
+	shuffled := shuffleModules(modules, r.shuffleSeed)
+
+	names := make([]string, len(shuffled))
+	for i, module := range shuffled {
+		names[i] = module.Name()
+	}
+	r.logger.Info("Shuffling test order",
+		"seed", r.shuffleSeed,
+		"modules", names,
+	)
+	return shuffled
+}
+
+// runModules executes the given modules honoring declared dependencies and the
+// configured concurrency budget. A module starts only after all of its dependencies
+// that are part of this run have completed. Results are returned in the same order
+// as the modules slice so reports stay deterministic regardless of scheduling.
+// The second return value is the number of modules that never started because the
+// context was cancelled or timed out.
+func (r *Runner) runModules(ctx context.Context, modules []TestModule, policy SelectionPolicy) ([][]TestResult, int) {
+	results := make([][]TestResult, len(modules))
+
+	registered := make(map[string]bool, len(r.modules))
+	for _, module := range r.modules {
+		registered[module.Name()] = true
+	}
+
+	done := make(map[string]chan struct{}, len(modules))
+	for _, module := range modules {
+		done[module.Name()] = make(chan struct{})
+	}
+
+	slots := make(chan struct{}, r.concurrency)
+	abort := newFailFast(r.failFast, r.logger)
+
+	// When shuffling, modules acquire execution slots strictly in slice order so
+	// that a seed reproduces the start order
+	var started []chan struct{}
+	if r.shuffle {
+		started = make([]chan struct{}, len(modules))
+		for i := range started {
+			started[i] = make(chan struct{})
+		}
+	}
+
+	deadlines := newCategoryDeadlines(ctx, r.categoryTimeout)
+	defer deadlines.release()
+
+	// Suite-scoped setup runs before any module starts
+	suiteFixtures := newFixtures(ScopeSuite, r.logger)
+	setupErrs := r.setupSuite(ctx, modules, policy, suiteFixtures)
+
+	var mu sync.Mutex
+	notStarted := 0
+	markNotStarted := func(module TestModule) {
+		mu.Lock()
+		notStarted++
+		mu.Unlock()
+		r.logger.Warn("Module not started",
+			"module", module.Name(),
+			"reason", ctx.Err(),
+		)
+	}
+
+	var wg sync.WaitGroup
+	for i, module := range modules {
+		wg.Add(1)
+		go func(i int, module TestModule) {
+			defer wg.Done()
+			defer close(done[module.Name()])
+
+			var startOnce sync.Once
+			markStarted := func() {
+				if started != nil {
+					startOnce.Do(func() { close(started[i]) })
+				}
+			}
+			defer markStarted()
+
+			// Apply the selection policy before waiting on anything
+			if policy != nil {
+				if selected, reason := policy.SelectModule(module); !selected {
+					r.logger.Info("Module skipped by selection policy",
+						"module", module.Name(),
+						"reason", reason,
+					)
+					results[i] = withModuleTestID(module, []TestResult{
+						NewSkipResult(module.Name(), module.RequirementID(), module.Category(), reason),
+					})
+					r.events.moduleFinished(ctx, module, results[i])
+					return
+				}
+			}
+
+			if err, failed := setupErrs[module.Name()]; failed {
+				results[i] = withModuleTestID(module, blockedResults(module, "suite setup", err))
+				abort.record(module, results[i]...)
+				r.events.moduleFinished(ctx, module, results[i])
+				return
+			}
+
+			// Wait for dependencies that are part of this run
+			for _, dep := range moduleDependencies(module) {
+				depDone, ok := done[dep]
+				if !ok {
+					if !registered[dep] {
+						results[i] = []TestResult{
+							newModuleErrorResult(
+								module,
+								0,
+								fmt.Errorf("module depends on unregistered module: %s", dep),
+							),
+						}
+						r.events.moduleFinished(ctx, module, results[i])
+						return
+					}
+					// Registered but not selected for this run (e.g. RunCategory)
+					continue
+				}
+
+				r.logger.Debug("Module waiting for dependency",
+					"module", module.Name(),
+					"dependency", dep,
+				)
+
+				select {
+				case <-depDone:
+				case <-ctx.Done():
+					markNotStarted(module)
+					return
+				}
+			}
+
+			// Wait for the previous module in shuffled order to start
+			if started != nil && i > 0 {
+				select {
+				case <-started[i-1]:
+				case <-ctx.Done():
+					markNotStarted(module)
+					return
+				}
+			}
+
+			// Acquire an execution slot
+			select {
+			case slots <- struct{}{}:
+			case <-ctx.Done():
+				markNotStarted(module)
+				return
+			}
+			defer func() { <-slots }()
+			markStarted()
+
+			if ctx.Err() != nil {
+				markNotStarted(module)
+				return
+			}
+
+			// Abort modules whose fail-fast scope already failed
+			if err := abort.aborted(module); err != nil {
+				results[i] = withModuleTestID(module, blockedResults(module, "fail-fast", err))
+				r.events.moduleFinished(ctx, module, results[i])
+				return
+			}
+
+			// Bound the module by its category deadline
+			categoryCtx := deadlines.context(module.Category())
+			if categoryCtx.Err() != nil {
+				results[i] = []TestResult{
+					newModuleErrorResult(
+						module,
+						0,
+						fmt.Errorf("module not started: %w", context.Cause(categoryCtx)),
+					),
+				}
+				r.events.moduleFinished(ctx, module, results[i])
+				return
+			}
+
+			results[i] = r.runModule(categoryCtx, module, policy, abort)
+		}(i, module)
+	}
+
+	wg.Wait()
+
+	// Suite-scoped teardown runs after every module has finished
+	for i, module := range modules {
+		if _, failed := setupErrs[module.Name()]; failed {
+			continue
+		}
+		if hooks, ok := module.(SuiteHooks); ok && moduleSelected(policy, module) {
+			if err := callHook(ctx, func(ctx context.Context) error { return hooks.TeardownSuite(ctx, r.harness) }); err != nil {
+				results[i] = append(results[i], newModuleErrorResult(module, 0, fmt.Errorf("suite teardown failed: %w", err)))
+			}
+		}
+	}
+	if err := suiteFixtures.release(ctx); err != nil {
+		r.logger.Error("Failed to release suite fixtures",
+			"error", err,
+		)
+	}
+
+	return results, notStarted
+}
+
+// setupSuite runs SetupSuite for every selected module implementing SuiteHooks and
+// returns the setup errors by module name
+func (r *Runner) setupSuite(ctx context.Context, modules []TestModule, policy SelectionPolicy, fixtures *Fixtures) map[string]error {
+	errs := make(map[string]error)
+	for _, module := range modules {
+		hooks, ok := module.(SuiteHooks)
+		if !ok || !moduleSelected(policy, module) {
+			continue
+		}
+		if err := callSetup(ctx, func(ctx context.Context) error { return hooks.SetupSuite(ctx, r.harness, fixtures) }); err != nil {
+			r.logger.Warn("Suite setup failed",
+				"module", module.Name(),
+				"error", err,
+			)
+			errs[module.Name()] = err
+		}
+	}
+	return errs
+}
+
+// moduleSelected reports whether the policy lets a module run
+func moduleSelected(policy SelectionPolicy, module TestModule) bool {
+	if policy == nil {
+		return true
+	}
+	selected, _ := policy.SelectModule(module)
+	return selected
+}
+
+// blockedResults reports the tests of a module that could not run because a setup
+// hook failed. A SkipError skips every test with its reason; any other error is
+// reported once as a module error and the tests are skipped.
+func blockedResults(module TestModule, stage string, err error) []TestResult {
+	reason, skipped := skipReason(err)
+	if !skipped {
+		reason = fmt.Sprintf("Not run: %s failed for module %s", stage, module.Name())
+	}
+
+	var results []TestResult
+	if !skipped {
+		results = append(results, newModuleErrorResult(module, 0, fmt.Errorf("%s failed: %w", stage, err)))
+	}
+
+	cases := moduleCases(module)
+	if cases == nil && skipped {
+		return []TestResult{NewSkipResult(module.Name(), module.RequirementID(), module.Category(), reason)}
+	}
+	for _, tc := range cases {
+		result := NewSkipResult(tc.Name, tc.RequirementID, module.Category(), reason)
+		result.TestID = QualifiedTestID(module.Name(), tc.ID)
+		results = append(results, result)
+	}
+	return results
+}
+
+// runModule executes a single test module under a selection policy and publishes
+// its progress events. Failures are recorded for fail-fast. Results of modules without test cases are filtered by the
+// policy after the module returns.
+// Module setup runs first; module teardown and fixtures are released afterwards
+// even if the module panicked or timed out.
+func (r *Runner) runModule(ctx context.Context, module TestModule, policy SelectionPolicy, abort *failFast) []TestResult {
+	run := &moduleRun{testTimeout: r.testTimeout, retry: r.retry, events: r.events, failFast: abort}
+	if r.shuffle {
+		run.shuffle = newShuffleRand(r.shuffleSeed, module.Name())
+	}
+	fixtures := newFixtures(ScopeModule, r.logger)
+	moduleCtx := withFixtures(withSelection(ctx, policy, module), fixtures)
+
+	r.events.moduleStarted(ctx, module)
+
+	var results []TestResult
+	hooks, hasHooks := module.(ModuleHooks)
+	if hasHooks {
+		err := callSetup(moduleCtx, func(ctx context.Context) error { return hooks.SetupModule(ctx, r.harness, fixtures) })
+		if err != nil {
+			r.logger.Warn("Module setup failed",
+				"module", module.Name(),
+				"error", err,
+			)
+			results = withModuleTestID(module, blockedResults(module, "module setup", err))
+		}
+	}
+	if results == nil {
+		results = r.executeModule(moduleCtx, module, run)
+	}
+
+	// Release module resources regardless of how the module ended
+	var cleanupErrs []error
+	if hasHooks {
+		if err := callHook(ctx, func(ctx context.Context) error { return hooks.TeardownModule(ctx, r.harness) }); err != nil {
+			cleanupErrs = append(cleanupErrs, err)
+		}
+	}
+	if err := fixtures.release(ctx); err != nil {
+		cleanupErrs = append(cleanupErrs, err)
+	}
+	if err := errors.Join(cleanupErrs...); err != nil {
+		results = append(results, newModuleErrorResult(module, 0, fmt.Errorf("module teardown failed: %w", err)))
+	}
+
+	results = applySelection(policy, module, results)
+	abort.record(module, results...)
+	r.events.moduleFinished(ctx, module, run.unannounced(results))
+
+	return results
+}
+
+// executeModule executes a single test module with panic recovery
+// Each module receives a context carrying the per-test timeout and a recorder for
+// results reported via Report. If the module context ends before the module returns,
+// the runner waits up to the shutdown grace period for the module to observe the
+// cancellation and exit, so no harness calls outlive the suite.
+func (r *Runner) executeModule(ctx context.Context, module TestModule, run *moduleRun) []TestResult {
 	moduleName := module.Name()
 	startTime := time.Now()
 
@@ -201,33 +642,44 @@ func (r *Runner) runModule(ctx context.Context, module TestModule) []TestResult
 		"requirement", module.RequirementID(),
 	)
 
+	moduleCtx := withModuleRun(ctx, run)
+
 	// Use a separate goroutine with panic recovery
 	resultsChan := make(chan []TestResult, 1)
-	panicChan := make(chan any, 1)
-
-	var wg sync.WaitGroup
-	wg.Add(1)
+	panicChan := make(chan *TestError, 1)
 
 	go func() {
-		defer wg.Done()
 		defer func() {
 			if panicErr := recover(); panicErr != nil {
 				// Capture panic and stack trace
-				stackTrace := string(debug.Stack())
+				stackTrace := debug.Stack()
 				r.logger.Error("Module panicked",
 					"module", moduleName,
 					"panic", panicErr,
-					"stack", stackTrace,
+					"stack", string(stackTrace),
 				)
-				panicChan <- panicErr
+				panicChan <- PanicError(panicErr, stackTrace)
 			}
 		}()
 
-		// Execute the module
-		results := module.Run(ctx, r.harness)
-		resultsChan <- results
+		// Execute the module, one test case at a time if it provides them
+		var results []TestResult
+		if cases := moduleCases(module); cases != nil {
+			results = RunCases(moduleCtx, r.harness, module, cases)
+		} else {
+			results = module.Run(moduleCtx, r.harness)
+		}
+		resultsChan <- withModuleTestID(module, results)
 	}()
 
+	panicResult := func(panicErr *TestError) TestResult {
+		return newModuleErrorResult(
+			module,
+			time.Since(startTime),
+			fmt.Errorf("module panicked: %w", panicErr),
+		)
+	}
+
 	// Wait for completion or timeout
 	select {
 	case results := <-resultsChan:
@@ -240,17 +692,8 @@ func (r *Runner) runModule(ctx context.Context, module TestModule) []TestResult
 		return results
 
 	case panicErr := <-panicChan:
-		// Module panicked, return error result
-		duration := time.Since(startTime)
-		return []TestResult{
-			NewErrorResult(
-				moduleName,
-				module.RequirementID(),
-				module.Category(),
-				duration,
-				fmt.Errorf("module panicked: %v", panicErr),
-			),
-		}
+		// Module panicked, keep what it reported before the panic
+		return append(withModuleTestID(module, run.results()), panicResult(panicErr))
 
 	case <-ctx.Done():
 		// Context cancelled or timed out
@@ -258,19 +701,100 @@ func (r *Runner) runModule(ctx context.Context, module TestModule) []TestResult
 		r.logger.Warn("Module execution timed out or cancelled",
 			"module", moduleName,
 			"duration", duration,
+			"cause", context.Cause(ctx),
 		)
-		return []TestResult{
-			NewErrorResult(
-				moduleName,
-				module.RequirementID(),
-				module.Category(),
-				duration,
-				fmt.Errorf("module timed out: %w", ctx.Err()),
-			),
+		timeoutResult := newModuleErrorResult(
+			module,
+			duration,
+			fmt.Errorf("module timed out: %w", context.Cause(ctx)),
+		)
+
+		// Give the module a bounded amount of time to observe the cancellation
+		grace := time.NewTimer(r.shutdownGrace)
+		defer grace.Stop()
+
+		select {
+		case results := <-resultsChan:
+			return append(results, timeoutResult)
+		case panicErr := <-panicChan:
+			return append(withModuleTestID(module, run.results()), panicResult(panicErr), timeoutResult)
+		case <-grace.C:
+			r.logger.Error("Module did not exit within shutdown grace period",
+				"module", moduleName,
+				"grace_period", r.shutdownGrace,
+			)
+			return append(withModuleTestID(module, run.results()), timeoutResult)
 		}
 	}
 }
 
+// newModuleErrorResult creates an error result attributed to a whole module
+func newModuleErrorResult(module TestModule, duration time.Duration, err error) TestResult {
+	result := NewErrorResult(module.Name(), module.RequirementID(), module.Category(), duration, err)
+	result.TestID = module.Name()
+	return result
+}
+
+// withModuleTestID sets the test ID of results that do not carry one to the
+// module name, so that tests of modules without test cases can be re-run
+func withModuleTestID(module TestModule, results []TestResult) []TestResult {
+	for i := range results {
+		if results[i].TestID == "" {
+			results[i].TestID = module.Name()
+		}
+	}
+	return results
+}
+
+// categoryDeadlines lazily creates one deadline-bound context per category
+// so that a category's clock only starts when its first module starts
+type categoryDeadlines struct {
+	mu       sync.Mutex
+	parent   context.Context
+	timeout  time.Duration
+	contexts map[Category]context.Context
+	cancels  []context.CancelFunc
+}
+
+// newCategoryDeadlines creates category deadlines derived from parent
+// A zero timeout disables category deadlines
+func newCategoryDeadlines(parent context.Context, timeout time.Duration) *categoryDeadlines {
+	return &categoryDeadlines{
+		parent:   parent,
+		timeout:  timeout,
+		contexts: make(map[Category]context.Context),
+	}
+}
+
+// context returns the context for a category, starting its clock on first use
+func (d *categoryDeadlines) context(category Category) context.Context {
+	if d.timeout <= 0 {
+		return d.parent
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if ctx, ok := d.contexts[category]; ok {
+		return ctx
+	}
+
+	ctx, cancel := context.WithTimeoutCause(d.parent, d.timeout,
+		fmt.Errorf("category %s exceeded its timeout of %v: %w", category, d.timeout, context.DeadlineExceeded))
+	d.contexts[category] = ctx
+	d.cancels = append(d.cancels, cancel)
+	return ctx
+}
+
+// release cancels all category contexts
+func (d *categoryDeadlines) release() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, cancel := range d.cancels {
+		cancel()
+	}
+}
+
 // GetModules returns all registered modules
 func (r *Runner) GetModules() []TestModule {
 	return r.modules
@@ -0,0 +1,475 @@
+package runner
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/zero-day-ai/sdk/agent"
+)
+
+// stubHarness satisfies agent.Harness for runner tests
+// Only Logger is implemented; any other call panics, which surfaces unexpected harness use
+type stubHarness struct {
+	agent.Harness
+	logger *slog.Logger
+}
+
+func newStubHarness() *stubHarness {
+	return &stubHarness{
+		logger: slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError})),
+	}
+}
+
+func (s *stubHarness) Logger() *slog.Logger {
+	return s.logger
+}
+
+// fakeModule is a configurable TestModule for runner tests
+type fakeModule struct {
+	name     string
+	category Category
+	deps     []string
+	run      func(ctx context.Context) []TestResult
+}
+
+func (f *fakeModule) Name() string          { return f.name }
+func (f *fakeModule) Description() string   { return "fake module " + f.name }
+func (f *fakeModule) Category() Category    { return f.category }
+func (f *fakeModule) RequirementID() string { return "T-" + f.name }
+func (f *fakeModule) DependsOn() []string   { return f.deps }
+
+func (f *fakeModule) Run(ctx context.Context, h agent.Harness) []TestResult {
+	if f.run != nil {
+		return f.run(ctx)
+	}
+	return []TestResult{NewPassResult(f.name, f.RequirementID(), f.category, 0, "ok")}
+}
+
+func newFakeModule(name string, deps ...string) *fakeModule {
+	return &fakeModule{name: name, category: CategorySDK, deps: deps}
+}
+
+func TestRegisterModule_RejectsDuplicates(t *testing.T) {
+	r := NewRunner(newStubHarness(), time.Minute, time.Second)
+
+	if err := r.RegisterModule(newFakeModule("a")); err != nil {
+		t.Fatalf("RegisterModule() unexpected error: %v", err)
+	}
+	if err := r.RegisterModule(newFakeModule("a")); err == nil {
+		t.Fatal("RegisterModule() expected error for duplicate module name")
+	}
+	if got := len(r.GetModules()); got != 1 {
+		t.Errorf("GetModules() = %d modules, want 1", got)
+	}
+}
+
+func TestRegisterModule_DetectsCycles(t *testing.T) {
+	tests := []struct {
+		name    string
+		modules []*fakeModule
+		wantErr string
+	}{
+		{
+			name:    "self dependency",
+			modules: []*fakeModule{newFakeModule("a", "a")},
+			wantErr: "depends on itself",
+		},
+		{
+			name: "two module cycle",
+			modules: []*fakeModule{
+				newFakeModule("a", "b"),
+				newFakeModule("b", "a"),
+			},
+			wantErr: "b -> a -> b",
+		},
+		{
+			name: "three module cycle",
+			modules: []*fakeModule{
+				newFakeModule("a", "c"),
+				newFakeModule("b", "a"),
+				newFakeModule("c", "b"),
+			},
+			wantErr: "dependency cycle detected",
+		},
+		{
+			name: "diamond is not a cycle",
+			modules: []*fakeModule{
+				newFakeModule("a"),
+				newFakeModule("b", "a"),
+				newFakeModule("c", "a"),
+				newFakeModule("d", "b", "c"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRunner(newStubHarness(), time.Minute, time.Second)
+
+			var err error
+			for _, m := range tt.modules {
+				if err = r.RegisterModule(m); err != nil {
+					break
+				}
+			}
+
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("RegisterModule() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("RegisterModule() expected error containing %q", tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("RegisterModule() error = %q, want it to contain %q", err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRun_DependenciesRunFirst(t *testing.T) {
+	var mu sync.Mutex
+	order := []string{}
+	record := func(name string) func(ctx context.Context) []TestResult {
+		return func(ctx context.Context) []TestResult {
+			time.Sleep(10 * time.Millisecond)
+			mu.Lock()
+			order = append(order, name)
+			mu.Unlock()
+			return []TestResult{NewPassResult(name, "T", CategorySDK, 0, "ok")}
+		}
+	}
+
+	scan := newFakeModule("scan")
+	scan.run = record("scan")
+	analyze := newFakeModule("analyze", "scan")
+	analyze.run = record("analyze")
+	report := newFakeModule("report", "analyze", "scan")
+	report.run = record("report")
+
+	r := NewRunner(newStubHarness(), time.Minute, time.Second)
+	r.SetConcurrency(4)
+	// Register out of dependency order to make sure ordering comes from the graph
+	if err := r.RegisterModules(report, analyze, scan); err != nil {
+		t.Fatalf("RegisterModules() unexpected error: %v", err)
+	}
+
+	suite, err := r.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+
+	want := []string{"scan", "analyze", "report"}
+	if strings.Join(order, ",") != strings.Join(want, ",") {
+		t.Errorf("execution order = %v, want %v", order, want)
+	}
+
+	// Results are reported in registration order
+	gotNames := []string{}
+	for _, result := range suite.Results {
+		gotNames = append(gotNames, result.TestName)
+	}
+	if strings.Join(gotNames, ",") != "report,analyze,scan" {
+		t.Errorf("result order = %v, want registration order", gotNames)
+	}
+}
+
+func TestRun_RespectsConcurrencyBudget(t *testing.T) {
+	var running, maxRunning atomic.Int32
+	work := func(ctx context.Context) []TestResult {
+		n := running.Add(1)
+		for {
+			current := maxRunning.Load()
+			if n <= current || maxRunning.CompareAndSwap(current, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		running.Add(-1)
+		return []TestResult{NewPassResult("work", "T", CategorySDK, 0, "ok")}
+	}
+
+	r := NewRunner(newStubHarness(), time.Minute, time.Second)
+	r.SetConcurrency(2)
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		m := newFakeModule(name)
+		m.run = work
+		if err := r.RegisterModule(m); err != nil {
+			t.Fatalf("RegisterModule() unexpected error: %v", err)
+		}
+	}
+
+	suite, err := r.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+	if suite.TotalTests() != 5 {
+		t.Errorf("TotalTests() = %d, want 5", suite.TotalTests())
+	}
+	if got := maxRunning.Load(); got != 2 {
+		t.Errorf("max concurrent modules = %d, want 2", got)
+	}
+}
+
+func TestRun_UnregisteredDependency(t *testing.T) {
+	r := NewRunner(newStubHarness(), time.Minute, time.Second)
+	if err := r.RegisterModule(newFakeModule("a", "missing")); err != nil {
+		t.Fatalf("RegisterModule() unexpected error: %v", err)
+	}
+
+	suite, err := r.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+	if suite.TotalErrors() != 1 {
+		t.Fatalf("TotalErrors() = %d, want 1", suite.TotalErrors())
+	}
+	if !strings.Contains(suite.Results[0].Message, "unregistered module: missing") {
+		t.Errorf("error message = %q, want mention of missing dependency", suite.Results[0].Message)
+	}
+}
+
+func TestRun_SelectionPolicy(t *testing.T) {
+	multi := newFakeModule("multi")
+	multi.run = func(ctx context.Context) []TestResult {
+		return []TestResult{
+			NewPassResult("First", "M-1", CategorySDK, 0, "ok"),
+			NewPassResult("Second", "M-2", CategorySDK, 0, "ok"),
+			NewFailResult("Third", "M-3", CategorySDK, 0, "broken", nil),
+		}
+	}
+	framework := newFakeModule("framework")
+	framework.category = CategoryFramework
+
+	tests := []struct {
+		name       string
+		policy     Selection
+		wantStatus map[string]TestStatus
+		wantReason map[string]string
+	}{
+		{
+			name:   "skip category",
+			policy: Selection{SkipCategories: []string{"framework"}},
+			wantStatus: map[string]TestStatus{
+				"First": TestStatusPass, "Second": TestStatusPass, "Third": TestStatusFail,
+				"framework": TestStatusSkip,
+			},
+			wantReason: map[string]string{"framework": "skip_categories"},
+		},
+		{
+			name:   "skip test by name and requirement",
+			policy: Selection{SkipTests: []string{"Second", "M-3"}},
+			wantStatus: map[string]TestStatus{
+				"First": TestStatusPass, "Second": TestStatusSkip, "Third": TestStatusSkip,
+				"framework": TestStatusPass,
+			},
+			wantReason: map[string]string{"Second": "skip_tests", "Third": "skip_tests"},
+		},
+		{
+			name:   "target single test",
+			policy: Selection{Targets: []string{"First"}},
+			wantStatus: map[string]TestStatus{
+				"First": TestStatusPass, "Second": TestStatusSkip, "Third": TestStatusSkip,
+				"framework": TestStatusSkip,
+			},
+			wantReason: map[string]string{"Second": "not in target list"},
+		},
+		{
+			name:   "target whole module",
+			policy: Selection{Targets: []string{"framework"}},
+			wantStatus: map[string]TestStatus{
+				"First": TestStatusSkip, "Second": TestStatusSkip, "Third": TestStatusSkip,
+				"framework": TestStatusPass,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRunner(newStubHarness(), time.Minute, time.Second)
+			r.SetSelectionPolicy(tt.policy)
+			if err := r.RegisterModules(multi, framework); err != nil {
+				t.Fatalf("RegisterModules() unexpected error: %v", err)
+			}
+
+			suite, err := r.Run(context.Background())
+			if err != nil {
+				t.Fatalf("Run() unexpected error: %v", err)
+			}
+
+			got := map[string]TestResult{}
+			for _, result := range suite.Results {
+				got[result.TestName] = result
+			}
+			for name, want := range tt.wantStatus {
+				if got[name].Status != want {
+					t.Errorf("%s status = %s, want %s", name, got[name].Status, want)
+				}
+			}
+			for name, want := range tt.wantReason {
+				if !strings.Contains(got[name].Message, want) {
+					t.Errorf("%s message = %q, want it to contain %q", name, got[name].Message, want)
+				}
+			}
+		})
+	}
+}
+
+func TestTestSelected_ConsultedByModule(t *testing.T) {
+	called := false
+	m := newFakeModule("gated")
+	m.run = func(ctx context.Context) []TestResult {
+		if selected, reason := TestSelected(ctx, "Expensive", "G-1"); !selected {
+			return []TestResult{NewSkipResult("Expensive", "G-1", CategorySDK, reason)}
+		}
+		called = true
+		return []TestResult{NewPassResult("Expensive", "G-1", CategorySDK, 0, "ok")}
+	}
+
+	r := NewRunner(newStubHarness(), time.Minute, time.Second)
+	r.SetSelectionPolicy(Selection{SkipTests: []string{"G-1"}})
+	if err := r.RegisterModule(m); err != nil {
+		t.Fatalf("RegisterModule() unexpected error: %v", err)
+	}
+
+	suite, err := r.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+	if called {
+		t.Error("module performed work for a test excluded by the selection policy")
+	}
+	if suite.TotalSkipped() != 1 {
+		t.Errorf("TotalSkipped() = %d, want 1", suite.TotalSkipped())
+	}
+}
+
+func TestRun_CategoryTimeout(t *testing.T) {
+	slow := newFakeModule("slow")
+	slow.run = func(ctx context.Context) []TestResult {
+		<-ctx.Done()
+		return nil
+	}
+	other := newFakeModule("other")
+	other.category = CategoryFramework
+
+	r := NewRunner(newStubHarness(), time.Minute, time.Second)
+	r.SetCategoryTimeout(50 * time.Millisecond)
+	if err := r.RegisterModules(slow, other); err != nil {
+		t.Fatalf("RegisterModules() unexpected error: %v", err)
+	}
+
+	suite, err := r.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+
+	if suite.Results[0].Status != TestStatusError {
+		t.Fatalf("slow module status = %s, want error", suite.Results[0].Status)
+	}
+	if !strings.Contains(suite.Results[0].Message, "category sdk exceeded its timeout") {
+		t.Errorf("slow module message = %q, want category timeout cause", suite.Results[0].Message)
+	}
+	if suite.Results[1].Status != TestStatusPass {
+		t.Errorf("other category status = %s, want pass", suite.Results[1].Status)
+	}
+}
+
+func TestTestContext_Deadline(t *testing.T) {
+	tests := []struct {
+		name        string
+		testTimeout time.Duration
+		minimum     time.Duration
+		want        time.Duration
+	}{
+		{name: "runner test timeout", testTimeout: time.Second, minimum: 0, want: time.Second},
+		{name: "minimum extends timeout", testTimeout: time.Second, minimum: time.Minute, want: time.Minute},
+		{name: "no timeout configured", testTimeout: 0, minimum: 0, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := withModuleRun(context.Background(), &moduleRun{testTimeout: tt.testTimeout})
+			testCtx, cancel := TestContext(ctx, tt.minimum)
+			defer cancel()
+
+			deadline, ok := testCtx.Deadline()
+			if tt.want == 0 {
+				if ok {
+					t.Errorf("TestContext() deadline set, want none")
+				}
+				return
+			}
+			if !ok {
+				t.Fatal("TestContext() deadline not set")
+			}
+			if remaining := time.Until(deadline); remaining > tt.want || remaining < tt.want-time.Second {
+				t.Errorf("TestContext() remaining = %v, want about %v", remaining, tt.want)
+			}
+		})
+	}
+}
+
+func TestRun_TimeoutKeepsReportedResults(t *testing.T) {
+	partial := newFakeModule("partial")
+	partial.run = func(ctx context.Context) []TestResult {
+		Report(ctx, NewPassResult("first", "T-1", CategorySDK, 0, "done"))
+		<-ctx.Done()
+		return []TestResult{NewPassResult("first", "T-1", CategorySDK, 0, "done")}
+	}
+
+	r := NewRunner(newStubHarness(), 50*time.Millisecond, time.Second)
+	if err := r.RegisterModule(partial); err != nil {
+		t.Fatalf("RegisterModule() unexpected error: %v", err)
+	}
+
+	suite, _ := r.Run(context.Background())
+	if len(suite.Results) != 2 {
+		t.Fatalf("Run() = %d results, want 2 (reported result + timeout)", len(suite.Results))
+	}
+	if suite.Results[0].TestName != "first" || suite.Results[0].Status != TestStatusPass {
+		t.Errorf("Results[0] = %s/%s, want first/pass", suite.Results[0].TestName, suite.Results[0].Status)
+	}
+	if suite.Results[1].Status != TestStatusError {
+		t.Errorf("Results[1].Status = %s, want error", suite.Results[1].Status)
+	}
+}
+
+func TestRun_ShutdownGraceBounded(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	stuck := newFakeModule("stuck")
+	stuck.run = func(ctx context.Context) []TestResult {
+		Report(ctx, NewPassResult("before-hang", "T-1", CategorySDK, 0, "done"))
+		<-release // ignores cancellation
+		return nil
+	}
+
+	r := NewRunner(newStubHarness(), 50*time.Millisecond, time.Second)
+	r.SetShutdownGrace(50 * time.Millisecond)
+	if err := r.RegisterModule(stuck); err != nil {
+		t.Fatalf("RegisterModule() unexpected error: %v", err)
+	}
+
+	start := time.Now()
+	suite, _ := r.Run(context.Background())
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Run() took %v, want it bounded by timeout plus shutdown grace", elapsed)
+	}
+
+	if len(suite.Results) != 2 {
+		t.Fatalf("Run() = %d results, want 2 (reported result + timeout)", len(suite.Results))
+	}
+	if !strings.Contains(suite.Results[1].Message, "module timed out") {
+		t.Errorf("Results[1].Message = %q, want module timeout", suite.Results[1].Message)
+	}
+}
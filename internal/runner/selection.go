@@ -0,0 +1,176 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// SelectionPolicy decides which modules and tests the runner executes.
+// When a policy rejects a module or test it returns the reason, which the runner
+// reports as the message of the resulting skip result.
+type SelectionPolicy interface {
+	// SelectModule reports whether a module should run at all
+	SelectModule(module TestModule) (bool, string)
+
+	// SelectTest reports whether an individual test within a module should run
+	SelectTest(module TestModule, test TestRef) (bool, string)
+}
+
+// RestrictionPolicy is implemented by selection policies with exclusions that also
+// hold for prerequisites. Prerequisites of selected tests normally run even when
+// the policy excludes them; tests a restriction forbids never run, and tests that
+// depend on them are skipped.
+type RestrictionPolicy interface {
+	// ForbidTest reports whether a test must not run, and why
+	ForbidTest(module TestModule, test TestRef) (bool, string)
+}
+
+// TagActive marks tests that actively scan the network (ping sweeps, port scans)
+const TagActive = "active"
+
+// TestRef identifies a test to a SelectionPolicy
+// ID and Tags are only known for tests declared as TestCase
+type TestRef struct {
+	// ID is the qualified test identifier ("<module>/<id>")
+	ID string
+
+	// Name is the human-readable test name
+	Name string
+
+	// RequirementID identifies which requirement the test validates
+	RequirementID string
+
+	// Tags group related tests for selection
+	Tags []string
+}
+
+// matches reports whether a target list entry names this test
+func (t TestRef) matches(entry string) bool {
+	if entry == "" {
+		return false
+	}
+	return entry == t.Name ||
+		entry == t.ID ||
+		entry == t.RequirementID ||
+		slices.Contains(t.Tags, entry)
+}
+
+// Selection is the standard SelectionPolicy built from target and skip lists.
+// Entries in Targets and SkipTests may name a module, a test (by name or qualified ID),
+// a requirement ID or a tag.
+type Selection struct {
+	// Targets restricts execution to the listed modules/tests (empty means everything)
+	Targets []string
+
+	// SkipTests lists modules/tests that must not run
+	SkipTests []string
+
+	// SkipCategories lists categories (e.g. "sdk", "framework") that must not run
+	SkipCategories []string
+
+	// ForbidTags maps tags of tests that must never run, not even as prerequisites,
+	// to the reason reported for them (e.g. TagActive when scanning is not allowed)
+	ForbidTags map[string]string
+}
+
+// SelectModule implements SelectionPolicy
+func (s Selection) SelectModule(module TestModule) (bool, string) {
+	if slices.Contains(s.SkipCategories, string(module.Category())) {
+		return false, fmt.Sprintf("Category %s skipped by configuration (skip_categories)", module.Category())
+	}
+
+	if slices.Contains(s.SkipTests, module.Name()) {
+		return false, fmt.Sprintf("Module %s skipped by configuration (skip_tests)", module.Name())
+	}
+
+	// With targets set, a module may still contain targeted tests even if the module
+	// itself is not named, so it runs and its individual tests are filtered
+	return true, ""
+}
+
+// SelectTest implements SelectionPolicy
+func (s Selection) SelectTest(module TestModule, test TestRef) (bool, string) {
+	if forbidden, reason := s.ForbidTest(module, test); forbidden {
+		return false, reason
+	}
+
+	if slices.ContainsFunc(s.SkipTests, test.matches) {
+		return false, fmt.Sprintf("Test %s skipped by configuration (skip_tests)", test.Name)
+	}
+
+	if len(s.Targets) == 0 {
+		return true, ""
+	}
+
+	if slices.Contains(s.Targets, module.Name()) || slices.ContainsFunc(s.Targets, test.matches) {
+		return true, ""
+	}
+
+	return false, fmt.Sprintf("Test %s not in target list (tests)", test.Name)
+}
+
+// ForbidTest implements RestrictionPolicy
+func (s Selection) ForbidTest(module TestModule, test TestRef) (bool, string) {
+	for _, tag := range test.Tags {
+		if reason, forbidden := s.ForbidTags[tag]; forbidden {
+			return true, fmt.Sprintf("Test %s skipped: %s", test.Name, reason)
+		}
+	}
+	return false, ""
+}
+
+// selectionKey is the context key under which the active module selection is stored
+type selectionKey struct{}
+
+// moduleSelection binds a selection policy to the module currently executing
+type moduleSelection struct {
+	policy SelectionPolicy
+	module TestModule
+}
+
+// withSelection returns a context carrying the selection policy for a module
+func withSelection(ctx context.Context, policy SelectionPolicy, module TestModule) context.Context {
+	if policy == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, selectionKey{}, moduleSelection{policy: policy, module: module})
+}
+
+// TestSelected reports whether the runner's selection policy allows a test to run.
+// Modules should consult it before doing expensive work (tool calls, LLM requests)
+// for a test, and report a skip with the returned reason when it is not selected.
+// Without a policy in the context every test is selected.
+func TestSelected(ctx context.Context, testName, requirementID string) (bool, string) {
+	sel, ok := ctx.Value(selectionKey{}).(moduleSelection)
+	if !ok {
+		return true, ""
+	}
+	return sel.policy.SelectTest(sel.module, TestRef{Name: testName, RequirementID: requirementID})
+}
+
+// applySelection converts results of tests rejected by the policy into skip results.
+// This covers modules that do not consult TestSelected themselves. Module-level error
+// results (named after the module) are kept so that failures are never hidden.
+// Modules providing test cases are left alone since the policy was applied per case.
+func applySelection(policy SelectionPolicy, module TestModule, results []TestResult) []TestResult {
+	if policy == nil || moduleCases(module) != nil {
+		return results
+	}
+
+	filtered := make([]TestResult, 0, len(results))
+	for _, result := range results {
+		if result.Status == TestStatusError && result.TestName == module.Name() {
+			filtered = append(filtered, result)
+			continue
+		}
+		ref := TestRef{ID: result.TestID, Name: result.TestName, RequirementID: result.RequirementID}
+		if selected, reason := policy.SelectTest(module, ref); !selected {
+			skip := NewSkipResult(result.TestName, result.RequirementID, result.Category, reason)
+			skip.TestID = result.TestID
+			result = skip
+		}
+		filtered = append(filtered, result)
+	}
+	return filtered
+}
@@ -0,0 +1,26 @@
+package runner
+
+import "testing"
+
+func TestApplySelection_KeepsTestID(t *testing.T) {
+	m := newFakeModule("recon")
+	kept := NewPassResult("Kept", "R-1", CategorySDK, 0, "ok")
+	kept.TestID = "recon/kept"
+	dropped := NewPassResult("Dropped", "R-2", CategorySDK, 0, "ok")
+	dropped.TestID = "recon/dropped"
+
+	results := applySelection(Selection{SkipTests: []string{"recon/dropped"}}, m, []TestResult{kept, dropped})
+
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2", len(results))
+	}
+	if results[0].Status != TestStatusPass || results[0].TestID != "recon/kept" {
+		t.Errorf("kept result = %s %q, want pass recon/kept", results[0].Status, results[0].TestID)
+	}
+	if results[1].Status != TestStatusSkip {
+		t.Errorf("dropped result status = %s, want skip", results[1].Status)
+	}
+	if results[1].TestID != "recon/dropped" {
+		t.Errorf("dropped result TestID = %q, want recon/dropped", results[1].TestID)
+	}
+}
@@ -0,0 +1,101 @@
+package runner
+
+import (
+	"hash/fnv"
+	"math/rand/v2"
+)
+
+// newShuffleRand returns the random source for one shuffle. Each module gets its
+// own stream derived from the seed and its name, so the order of its tests does
+// not depend on which other modules run.
+func newShuffleRand(seed int64, stream string) *rand.Rand {
+	h := fnv.New64a()
+	h.Write([]byte(stream))
+	return rand.New(rand.NewPCG(uint64(seed), h.Sum64()))
+}
+
+// shuffleOrder returns a random permutation of n items in which every item
+// comes after the items it depends on. deps returns the indexes item i depends on.
+func shuffleOrder(rng *rand.Rand, n int, deps func(i int) []int) []int {
+	pending := make([]int, n)
+	dependents := make([][]int, n)
+	for i := 0; i < n; i++ {
+		for _, dep := range deps(i) {
+			pending[i]++
+			dependents[dep] = append(dependents[dep], i)
+		}
+	}
+
+	var ready []int
+	for i := 0; i < n; i++ {
+		if pending[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+
+	order := make([]int, 0, n)
+	for len(ready) > 0 {
+		pick := rng.IntN(len(ready))
+		next := ready[pick]
+		ready = append(ready[:pick], ready[pick+1:]...)
+		order = append(order, next)
+
+		for _, dependent := range dependents[next] {
+			pending[dependent]--
+			if pending[dependent] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+	return order
+}
+
+// shuffleModules returns the modules in a random order that keeps every module
+// after the modules it depends on
+func shuffleModules(modules []TestModule, seed int64) []TestModule {
+	index := make(map[string]int, len(modules))
+	for i, module := range modules {
+		index[module.Name()] = i
+	}
+
+	order := shuffleOrder(newShuffleRand(seed, ""), len(modules), func(i int) []int {
+		var deps []int
+		for _, dep := range moduleDependencies(modules[i]) {
+			if j, ok := index[dep]; ok {
+				deps = append(deps, j)
+			}
+		}
+		return deps
+	})
+
+	shuffled := make([]TestModule, len(order))
+	for i, j := range order {
+		shuffled[i] = modules[j]
+	}
+	return shuffled
+}
+
+// shuffleCases returns test cases in a random order that keeps every case
+// after its prerequisites
+func shuffleCases(cases []TestCase, rng *rand.Rand) []TestCase {
+	index := make(map[string]int, len(cases))
+	for i, tc := range cases {
+		index[tc.ID] = i
+	}
+
+	order := shuffleOrder(rng, len(cases), func(i int) []int {
+		var deps []int
+		for _, prereq := range cases[i].Prerequisites {
+			if j, ok := index[prereq]; ok {
+				deps = append(deps, j)
+			}
+		}
+		return deps
+	})
+
+	shuffled := make([]TestCase, len(order))
+	for i, j := range order {
+		shuffled[i] = cases[j]
+	}
+	return shuffled
+}
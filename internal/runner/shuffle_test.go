@@ -0,0 +1,100 @@
+package runner
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestShuffleCases_KeepsPrerequisitesFirst(t *testing.T) {
+	var ran []string
+	cases := []TestCase{
+		recordingCase("parse", TestStatusPass, &ran),
+		recordingCase("ping", TestStatusPass, &ran, "parse"),
+		recordingCase("nmap", TestStatusPass, &ran, "ping"),
+		recordingCase("memory", TestStatusPass, &ran, "parse"),
+		recordingCase("llm", TestStatusPass, &ran, "nmap"),
+		recordingCase("findings", TestStatusPass, &ran, "nmap", "llm"),
+	}
+
+	orders := make(map[string]bool)
+	for seed := int64(0); seed < 50; seed++ {
+		shuffled := shuffleCases(cases, newShuffleRand(seed, "recon"))
+		if len(shuffled) != len(cases) {
+			t.Fatalf("seed %d: %d cases, want %d", seed, len(shuffled), len(cases))
+		}
+
+		position := make(map[string]int)
+		ids := make([]string, len(shuffled))
+		for i, tc := range shuffled {
+			position[tc.ID] = i
+			ids[i] = tc.ID
+		}
+		for _, tc := range shuffled {
+			for _, prereq := range tc.Prerequisites {
+				if position[prereq] > position[tc.ID] {
+					t.Errorf("seed %d: %s runs before its prerequisite %s: %v", seed, tc.ID, prereq, ids)
+				}
+			}
+		}
+		orders[strings.Join(ids, ",")] = true
+
+		again := shuffleCases(cases, newShuffleRand(seed, "recon"))
+		for i := range again {
+			if again[i].ID != shuffled[i].ID {
+				t.Fatalf("seed %d does not reproduce the order", seed)
+			}
+		}
+	}
+	if len(orders) < 3 {
+		t.Errorf("50 seeds produced only %d distinct orders", len(orders))
+	}
+}
+
+func TestRun_ShuffleIsReproducible(t *testing.T) {
+	run := func(seed int64) ([]string, *SuiteResult) {
+		var ran []string
+		modules := []TestModule{
+			newCaseModule("a", recordingCase("a1", TestStatusPass, &ran), recordingCase("a2", TestStatusPass, &ran)),
+			newCaseModule("b", recordingCase("b1", TestStatusPass, &ran), recordingCase("b2", TestStatusPass, &ran, "b1")),
+			newCaseModule("c", recordingCase("c1", TestStatusPass, &ran), recordingCase("c2", TestStatusPass, &ran)),
+		}
+		modules[1].(*caseModule).deps = []string{"a"}
+
+		r := NewRunner(newStubHarness(), time.Minute, time.Second)
+		r.SetShuffle(seed)
+		if err := r.RegisterModules(modules...); err != nil {
+			t.Fatal(err)
+		}
+		suite, err := r.Run(context.Background())
+		if err != nil {
+			t.Fatalf("Run() unexpected error: %v", err)
+		}
+		return ran, suite
+	}
+
+	first, suite := run(42)
+	if !suite.Shuffled || suite.ShuffleSeed != 42 {
+		t.Errorf("suite seed = %v/%d, want shuffled with seed 42", suite.Shuffled, suite.ShuffleSeed)
+	}
+	for i, result := range suite.Results {
+		if _, id, _ := strings.Cut(result.TestID, "/"); id != first[i] {
+			t.Errorf("Results[%d] = %s, want results in execution order %v", i, result.TestID, first)
+		}
+	}
+
+	position := make(map[string]int)
+	for i, id := range first {
+		position[id] = i
+	}
+	if position["b1"] > position["b2"] || position["a1"] > position["b1"] || position["a2"] > position["b1"] {
+		t.Errorf("order %v runs b before a or b2 before b1", first)
+	}
+
+	for attempt := 0; attempt < 5; attempt++ {
+		if again, _ := run(42); strings.Join(again, ",") != strings.Join(first, ",") {
+			t.Fatalf("seed 42 ran %v, then %v", first, again)
+		}
+	}
+}
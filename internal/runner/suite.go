@@ -23,6 +23,12 @@ type SuiteResult struct {
 
 	// OverallStatus is the overall suite status
 	OverallStatus TestStatus
+
+	// Shuffled reports whether modules and tests ran in random order
+	Shuffled bool
+
+	// ShuffleSeed is the seed that reproduces the order of a shuffled run
+	ShuffleSeed int64
 }
 
 // NewSuiteResult creates a new SuiteResult
@@ -70,12 +76,12 @@ func (sr *SuiteResult) Finalize() {
 	// Determine overall status
 	// If any errors, overall is error
 	// Else if any failures, overall is fail
-	// Else if all skipped, overall is skip
-	// Else overall is pass
+	// Else if all skipped or not implemented, overall is skip
+	// Else overall is pass (flaky tests do not fail the suite)
 	hasErrors := sr.SDKSummary.Errors > 0 || sr.FrameworkSummary.Errors > 0
 	hasFailures := sr.SDKSummary.Failed > 0 || sr.FrameworkSummary.Failed > 0
-	allSkipped := (sr.SDKSummary.Total == sr.SDKSummary.Skipped) &&
-		(sr.FrameworkSummary.Total == sr.FrameworkSummary.Skipped)
+	allSkipped := (sr.SDKSummary.Total == sr.SDKSummary.Skipped+sr.SDKSummary.NotImplemented) &&
+		(sr.FrameworkSummary.Total == sr.FrameworkSummary.Skipped+sr.FrameworkSummary.NotImplemented)
 
 	if hasErrors {
 		sr.OverallStatus = TestStatusError
@@ -118,11 +124,41 @@ func (sr *SuiteResult) TotalErrors() int {
 	return sr.SDKSummary.Errors + sr.FrameworkSummary.Errors
 }
 
+// TotalFlaky returns the total number of tests that passed after a retry
+func (sr *SuiteResult) TotalFlaky() int {
+	return sr.SDKSummary.Flaky + sr.FrameworkSummary.Flaky
+}
+
+// TotalNotImplemented returns the total number of tests whose checks do not exist yet
+func (sr *SuiteResult) TotalNotImplemented() int {
+	return sr.SDKSummary.NotImplemented + sr.FrameworkSummary.NotImplemented
+}
+
 // OverallPassRate returns the percentage of tests that passed (0.0 - 1.0)
+// Flaky tests count as passed since they eventually succeeded; tests that are
+// not implemented are left out, as they verify nothing
 func (sr *SuiteResult) OverallPassRate() float64 {
-	total := sr.TotalTests()
-	if total == 0 {
+	implemented := sr.TotalTests() - sr.TotalNotImplemented()
+	if implemented == 0 {
 		return 0
 	}
-	return float64(sr.TotalPassed()) / float64(total)
+	return float64(sr.TotalPassed()+sr.TotalFlaky()) / float64(implemented)
+}
+
+// FailedTestIDs returns the IDs of failed or errored tests, without duplicates,
+// suitable for passing to Runner.RunTests to re-run them
+func (sr *SuiteResult) FailedTestIDs() []string {
+	seen := make(map[string]bool)
+	var ids []string
+	for _, result := range sr.Results {
+		if result.Status != TestStatusFail && result.Status != TestStatusError {
+			continue
+		}
+		if result.TestID == "" || seen[result.TestID] {
+			continue
+		}
+		seen[result.TestID] = true
+		ids = append(ids, result.TestID)
+	}
+	return ids
 }
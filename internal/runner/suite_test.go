@@ -0,0 +1,77 @@
+package runner
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSuiteResult_NotImplemented(t *testing.T) {
+	tests := []struct {
+		name       string
+		results    []TestResult
+		wantStatus TestStatus
+		wantRate   float64
+	}{
+		{
+			name: "left out of the pass rate",
+			results: []TestResult{
+				NewPassResult("Context", "18", CategoryFramework, 0, "ok"),
+				NewNotImplementedResult("Daemon Service", "17", CategoryFramework, "requires daemon"),
+				NewNotImplementedResult("Prompt System", "27", CategoryFramework, "no check"),
+			},
+			wantStatus: TestStatusPass,
+			wantRate:   1,
+		},
+		{
+			name: "does not hide failures",
+			results: []TestResult{
+				NewFailResult("Context", "18", CategoryFramework, 0, "no mission", errors.New("empty ID")),
+				NewNotImplementedResult("Daemon Service", "17", CategoryFramework, "requires daemon"),
+			},
+			wantStatus: TestStatusFail,
+			wantRate:   0,
+		},
+		{
+			name: "only unverified tests",
+			results: []TestResult{
+				NewSkipResult("Neo4j", "30", CategoryFramework, "no database"),
+				NewNotImplementedResult("Daemon Service", "17", CategoryFramework, "requires daemon"),
+			},
+			wantStatus: TestStatusSkip,
+			wantRate:   0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suite := NewSuiteResult()
+			suite.AddResults(tt.results)
+			suite.Finalize()
+
+			if suite.OverallStatus != tt.wantStatus {
+				t.Errorf("OverallStatus = %s, want %s", suite.OverallStatus, tt.wantStatus)
+			}
+			if got := suite.OverallPassRate(); got != tt.wantRate {
+				t.Errorf("OverallPassRate() = %v, want %v", got, tt.wantRate)
+			}
+			if got := suite.FrameworkSummary.PassRate(); got != tt.wantRate {
+				t.Errorf("FrameworkSummary.PassRate() = %v, want %v", got, tt.wantRate)
+			}
+
+			var want int
+			for _, result := range tt.results {
+				if result.Status == TestStatusNotImplemented {
+					want++
+				}
+			}
+			if suite.FrameworkSummary.NotImplemented != want || suite.TotalNotImplemented() != want {
+				t.Errorf("NotImplemented = %d (total %d), want %d",
+					suite.FrameworkSummary.NotImplemented, suite.TotalNotImplemented(), want)
+			}
+			if suite.FrameworkSummary.Passed+suite.FrameworkSummary.Skipped+suite.FrameworkSummary.Failed+
+				suite.FrameworkSummary.NotImplemented != suite.FrameworkSummary.Total {
+				t.Errorf("FrameworkSummary = %+v, counts do not add up to Total", suite.FrameworkSummary)
+			}
+		})
+	}
+}
@@ -0,0 +1,459 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/zero-day-ai/sdk/agent"
+)
+
+// TestCase is a single, individually addressable check within a test module
+type TestCase struct {
+	// ID uniquely identifies the test within its module (e.g., "graphrag-storage")
+	// The suite-wide identifier is "<module>/<id>", see QualifiedTestID
+	ID string
+
+	// Name is the human-readable name reported in results
+	Name string
+
+	// RequirementID identifies which requirement this test validates (e.g., "NR-5")
+	RequirementID string
+
+	// Tags group related tests for selection (e.g., "network", "llm")
+	Tags []string
+
+	// Tools lists the tools the test invokes; dry runs check they are registered
+	Tools []string
+
+	// Plugins lists the plugins the test queries; dry runs check they are available
+	Plugins []string
+
+	// Prerequisites lists IDs of tests in the same module that must pass first
+	// Tests whose prerequisites did not pass are skipped
+	Prerequisites []string
+
+	// Timeout is the minimum deadline for this test; the runner's test timeout
+	// applies when it is longer. Each retry attempt gets a fresh deadline.
+	Timeout time.Duration
+
+	// Retry overrides the runner's retry policy for this test (nil uses the runner's)
+	Retry *RetryPolicy
+
+	// Run executes the test
+	Run func(ctx context.Context, h agent.Harness) TestResult
+}
+
+// CaseProvider is implemented by test modules whose tests can be enumerated.
+// The runner executes the cases of such modules one by one instead of calling
+// Run, which allows listing, selecting and re-running individual tests.
+type CaseProvider interface {
+	// TestCases returns the module's tests in execution order
+	TestCases() []TestCase
+}
+
+// TestInfo describes a registered test case for listing
+type TestInfo struct {
+	// ID is the qualified test identifier ("<module>/<id>")
+	ID string
+
+	// Module is the name of the module the test belongs to
+	Module string
+
+	// Name is the human-readable test name
+	Name string
+
+	// RequirementID identifies which requirement this test validates
+	RequirementID string
+
+	// Category indicates if this is an SDK or Framework test
+	Category Category
+
+	// Tags group related tests for selection
+	Tags []string
+
+	// Prerequisites lists the qualified IDs of tests that must pass first
+	Prerequisites []string
+}
+
+// QualifiedTestID returns the suite-wide identifier of a test case
+func QualifiedTestID(module, id string) string {
+	return module + "/" + id
+}
+
+// moduleCases returns the test cases of a module (nil if it does not provide any)
+func moduleCases(module TestModule) []TestCase {
+	if cp, ok := module.(CaseProvider); ok {
+		return cp.TestCases()
+	}
+	return nil
+}
+
+// validateCases checks that a module's test case IDs are unique and that every
+// prerequisite refers to an earlier test in the same module
+func validateCases(module TestModule) error {
+	seen := make(map[string]bool)
+	for _, tc := range moduleCases(module) {
+		if tc.ID == "" {
+			return fmt.Errorf("module %s has a test case without an ID: %q", module.Name(), tc.Name)
+		}
+		if tc.Run == nil {
+			return fmt.Errorf("test case %s has no Run function", QualifiedTestID(module.Name(), tc.ID))
+		}
+		if seen[tc.ID] {
+			return fmt.Errorf("duplicate test case ID in module %s: %s", module.Name(), tc.ID)
+		}
+		for _, prereq := range tc.Prerequisites {
+			if !seen[prereq] {
+				return fmt.Errorf("test case %s has prerequisite %s which is not an earlier test in the module",
+					QualifiedTestID(module.Name(), tc.ID), prereq)
+			}
+		}
+		seen[tc.ID] = true
+	}
+	return nil
+}
+
+// caseRunner executes the test cases of one module sequentially
+type caseRunner struct {
+	module TestModule
+	policy SelectionPolicy
+}
+
+// run executes the cases and returns one result per case, in shuffled order if
+// the runner shuffles tests.
+// Prerequisites of a selected case run even if the case itself was not selected,
+// since the case depends on the state they produce. Results are reported as each
+// case completes so they survive a module timeout.
+func (c caseRunner) run(ctx context.Context, h agent.Harness, cases []TestCase) []TestResult {
+	if run := moduleRunFrom(ctx); run != nil && run.shuffle != nil {
+		cases = shuffleCases(cases, run.shuffle)
+	}
+	selected := c.selectCases(cases)
+
+	statuses := make(map[string]TestStatus, len(cases))
+	results := make([]TestResult, 0, len(cases))
+
+	for _, tc := range cases {
+		result := c.runCase(ctx, h, tc, selected, statuses)
+		result.TestID = QualifiedTestID(c.module.Name(), tc.ID)
+		statuses[tc.ID] = result.Status
+		if run := moduleRunFrom(ctx); run != nil {
+			run.failFast.record(c.module, result)
+		}
+
+		results = append(results, result)
+		Report(ctx, result)
+		if run := moduleRunFrom(ctx); run != nil {
+			run.testFinished(ctx, c.module, result)
+		}
+	}
+
+	return results
+}
+
+// selectCases returns, per case ID, an empty string when the case runs or the
+// reason it was not selected
+func (c caseRunner) selectCases(cases []TestCase) map[string]string {
+	reasons := make(map[string]string, len(cases))
+	if c.policy == nil {
+		return reasons
+	}
+
+	for _, tc := range cases {
+		if ok, reason := c.policy.SelectTest(c.module, c.testRef(tc)); !ok {
+			reasons[tc.ID] = reason
+		}
+	}
+
+	// Pull in prerequisites of selected cases, walking backwards since
+	// prerequisites always precede the cases that depend on them. Cases a
+	// restriction forbids stay excluded.
+	restriction, _ := c.policy.(RestrictionPolicy)
+	byID := make(map[string]TestCase, len(cases))
+	for _, tc := range cases {
+		byID[tc.ID] = tc
+	}
+	for i := len(cases) - 1; i >= 0; i-- {
+		if _, excluded := reasons[cases[i].ID]; excluded {
+			continue
+		}
+		for _, prereq := range cases[i].Prerequisites {
+			if restriction != nil {
+				if forbidden, _ := restriction.ForbidTest(c.module, c.testRef(byID[prereq])); forbidden {
+					continue
+				}
+			}
+			delete(reasons, prereq)
+		}
+	}
+
+	return reasons
+}
+
+// runCase executes a single case after checking selection, fail-fast, prerequisites and cancellation
+func (c caseRunner) runCase(ctx context.Context, h agent.Harness, tc TestCase, selected map[string]string, statuses map[string]TestStatus) TestResult {
+	if reason, excluded := selected[tc.ID]; excluded {
+		return NewSkipResult(tc.Name, tc.RequirementID, c.module.Category(), reason)
+	}
+
+	if run := moduleRunFrom(ctx); run != nil {
+		if reason, aborted := skipReason(run.failFast.aborted(c.module)); aborted {
+			return NewSkipResult(tc.Name, tc.RequirementID, c.module.Category(), reason)
+		}
+	}
+
+	for _, prereq := range tc.Prerequisites {
+		if status := statuses[prereq]; !status.Succeeded() {
+			return NewSkipResult(tc.Name, tc.RequirementID, c.module.Category(),
+				fmt.Sprintf("Prerequisite %s did not pass (%s)", QualifiedTestID(c.module.Name(), prereq), status))
+		}
+	}
+
+	if err := context.Cause(ctx); err != nil {
+		return NewErrorResult(tc.Name, tc.RequirementID, c.module.Category(), 0,
+			fmt.Errorf("test not started: %w", err))
+	}
+
+	if run := moduleRunFrom(ctx); run != nil {
+		run.testStarted(ctx, c.module, QualifiedTestID(c.module.Name(), tc.ID), tc.Name)
+	}
+
+	return runWithRetry(ctx, c.retryPolicy(ctx, tc), func() TestResult {
+		return c.attempt(ctx, h, tc)
+	})
+}
+
+// attempt runs one attempt of a case within its own fixture scope, calling the
+// module's test hooks around it. Test fixtures are released and TeardownTest runs
+// even if the test panicked or exceeded its deadline.
+func (c caseRunner) attempt(ctx context.Context, h agent.Harness, tc TestCase) TestResult {
+	testCtx, cancel := TestContext(ctx, tc.Timeout)
+	defer cancel()
+
+	fixtures := newFixtures(ScopeTest, h.Logger())
+	testCtx = withFixtures(testCtx, fixtures)
+	hooks, hasHooks := c.module.(TestHooks)
+	ref := c.testRef(tc)
+
+	var result TestResult
+	var setupErr error
+	if hasHooks {
+		setupErr = callSetup(testCtx, func(ctx context.Context) error { return hooks.SetupTest(ctx, h, ref, fixtures) })
+	}
+	if reason, skipped := skipReason(setupErr); skipped {
+		result = NewSkipResult(tc.Name, tc.RequirementID, c.module.Category(), reason)
+	} else if setupErr != nil {
+		result = NewErrorResult(tc.Name, tc.RequirementID, c.module.Category(), 0,
+			fmt.Errorf("test setup failed: %w", setupErr))
+	} else {
+		result = c.execute(testCtx, h, tc)
+	}
+
+	var cleanupErrs []error
+	if hasHooks {
+		if err := callHook(ctx, func(ctx context.Context) error { return hooks.TeardownTest(ctx, h, ref) }); err != nil {
+			cleanupErrs = append(cleanupErrs, err)
+		}
+	}
+	if err := fixtures.release(ctx); err != nil {
+		cleanupErrs = append(cleanupErrs, err)
+	}
+	if err := errors.Join(cleanupErrs...); err != nil {
+		h.Logger().Warn("Test cleanup failed",
+			"module", c.module.Name(),
+			"test", tc.Name,
+			"error", err,
+		)
+		if result.Details == nil {
+			result.Details = make(map[string]any)
+		}
+		result.Details["cleanup_error"] = err.Error()
+	}
+
+	return result
+}
+
+// retryPolicy returns the retry policy for a case: its own, else the runner's
+func (c caseRunner) retryPolicy(ctx context.Context, tc TestCase) RetryPolicy {
+	if tc.Retry != nil {
+		return *tc.Retry
+	}
+	if run := moduleRunFrom(ctx); run != nil {
+		return run.retry
+	}
+	return NoRetry
+}
+
+// execute runs the test function with timing and panic recovery
+func (c caseRunner) execute(ctx context.Context, h agent.Harness, tc TestCase) TestResult {
+	return RunGuarded(ctx, h, c.testRef(tc), c.module.Category(), tc.Run)
+}
+
+// RunGuarded executes a test function with timing and panic recovery.
+// A panic becomes an error result that carries the panic value, the stack and
+// the identity of the test. Identity fields, category and duration missing from
+// the test's result are filled in, so a result is never left without a status.
+func RunGuarded(ctx context.Context, h agent.Harness, test TestRef, category Category,
+	fn func(ctx context.Context, h agent.Harness) TestResult) (result TestResult) {
+	startTime := time.Now()
+
+	defer func() {
+		if panicErr := recover(); panicErr != nil {
+			stack := debug.Stack()
+			h.Logger().Error("Test panicked",
+				"test_id", test.ID,
+				"test", test.Name,
+				"requirement_id", test.RequirementID,
+				"panic", panicErr,
+				"stack", string(stack),
+			)
+			result = NewErrorResult(test.Name, test.RequirementID, category, time.Since(startTime),
+				fmt.Errorf("test panicked: %w", PanicError(panicErr, stack)))
+			result.TestID = test.ID
+			result.Details["panic"] = fmt.Sprint(panicErr)
+		}
+	}()
+
+	result = fn(ctx, h)
+	if result.TestID == "" {
+		result.TestID = test.ID
+	}
+	if result.TestName == "" {
+		result.TestName = test.Name
+	}
+	if result.RequirementID == "" {
+		result.RequirementID = test.RequirementID
+	}
+	if result.Category == "" {
+		result.Category = category
+	}
+	if result.Status == "" {
+		result.Status = TestStatusError
+		result.Error = fmt.Errorf("test %s returned a result without a status", test.Name)
+		result.Message = result.Error.Error()
+	}
+	if result.Duration == 0 {
+		result.Duration = time.Since(startTime)
+	}
+	if result.Timestamp.IsZero() {
+		result.Timestamp = time.Now()
+	}
+	return result
+}
+
+// testRef describes a case to a selection policy
+func (c caseRunner) testRef(tc TestCase) TestRef {
+	return TestRef{
+		ID:            QualifiedTestID(c.module.Name(), tc.ID),
+		Name:          tc.Name,
+		RequirementID: tc.RequirementID,
+		Tags:          tc.Tags,
+	}
+}
+
+// RunCases executes test cases sequentially outside of a Runner, honoring
+// prerequisites, retry policies and per-test deadlines from the context. Modules implementing
+// CaseProvider use it to implement Run.
+func RunCases(ctx context.Context, h agent.Harness, module TestModule, cases []TestCase) []TestResult {
+	c := caseRunner{module: module}
+	if sel, ok := ctx.Value(selectionKey{}).(moduleSelection); ok {
+		c.policy = sel.policy
+	}
+	return c.run(ctx, h, cases)
+}
+
+// ListTests returns every registered test case in registration order.
+// Modules that do not provide test cases are listed as a single test named after
+// the module.
+func (r *Runner) ListTests() []TestInfo {
+	var tests []TestInfo
+	for _, module := range r.modules {
+		cases := moduleCases(module)
+		if cases == nil {
+			tests = append(tests, TestInfo{
+				ID:            module.Name(),
+				Module:        module.Name(),
+				Name:          module.Name(),
+				RequirementID: module.RequirementID(),
+				Category:      module.Category(),
+			})
+			continue
+		}
+
+		for _, tc := range cases {
+			prereqs := make([]string, len(tc.Prerequisites))
+			for i, prereq := range tc.Prerequisites {
+				prereqs[i] = QualifiedTestID(module.Name(), prereq)
+			}
+			tests = append(tests, TestInfo{
+				ID:            QualifiedTestID(module.Name(), tc.ID),
+				Module:        module.Name(),
+				Name:          tc.Name,
+				RequirementID: tc.RequirementID,
+				Category:      module.Category(),
+				Tags:          tc.Tags,
+				Prerequisites: prereqs,
+			})
+		}
+	}
+	return tests
+}
+
+// RunTests executes only the tests with the given qualified IDs (see ListTests),
+// together with their prerequisites. Modules without any requested test are not
+// run at all. This is typically used to re-run the failures of a previous suite.
+func (r *Runner) RunTests(ctx context.Context, ids ...string) (*SuiteResult, error) {
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("no tests requested")
+	}
+
+	known := make(map[string]bool)
+	for _, info := range r.ListTests() {
+		known[info.ID] = true
+	}
+	for _, id := range ids {
+		if !known[id] {
+			return nil, fmt.Errorf("test not found: %s", id)
+		}
+	}
+
+	var modules []TestModule
+	for _, module := range r.modules {
+		for _, id := range ids {
+			if id == module.Name() || strings.HasPrefix(id, module.Name()+"/") {
+				modules = append(modules, module)
+				break
+			}
+		}
+	}
+
+	r.logger.Info("Re-running selected tests",
+		"tests", ids,
+		"modules", len(modules),
+	)
+
+	return r.execute(ctx, modules, testIDSelection{ids: ids})
+}
+
+// testIDSelection selects tests by qualified ID
+type testIDSelection struct {
+	ids []string
+}
+
+// SelectModule implements SelectionPolicy
+func (s testIDSelection) SelectModule(module TestModule) (bool, string) {
+	return true, ""
+}
+
+// SelectTest implements SelectionPolicy
+func (s testIDSelection) SelectTest(module TestModule, test TestRef) (bool, string) {
+	if slices.Contains(s.ids, test.ID) || slices.Contains(s.ids, module.Name()) {
+		return true, ""
+	}
+	return false, fmt.Sprintf("Test %s not requested for re-run", test.Name)
+}
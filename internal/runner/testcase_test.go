@@ -0,0 +1,288 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zero-day-ai/sdk/agent"
+)
+
+// caseModule is a fakeModule that provides test cases
+type caseModule struct {
+	fakeModule
+	cases []TestCase
+}
+
+func (c *caseModule) TestCases() []TestCase { return c.cases }
+
+func newCaseModule(name string, cases ...TestCase) *caseModule {
+	return &caseModule{fakeModule: *newFakeModule(name), cases: cases}
+}
+
+// recordingCase returns a test case that records its execution and returns status
+func recordingCase(id string, status TestStatus, ran *[]string, prereqs ...string) TestCase {
+	return TestCase{
+		ID:            id,
+		Name:          "Test " + id,
+		RequirementID: "R-" + id,
+		Prerequisites: prereqs,
+		Run: func(ctx context.Context, h agent.Harness) TestResult {
+			*ran = append(*ran, id)
+			switch status {
+			case TestStatusFail:
+				return NewFailResult("Test "+id, "R-"+id, CategorySDK, 0, "failed", errors.New("failed"))
+			case TestStatusSkip:
+				return NewSkipResult("Test "+id, "R-"+id, CategorySDK, "skipped")
+			}
+			return NewPassResult("Test "+id, "R-"+id, CategorySDK, 0, "ok")
+		},
+	}
+}
+
+func TestRegisterModule_ValidatesCases(t *testing.T) {
+	var ran []string
+	tests := []struct {
+		name    string
+		cases   []TestCase
+		wantErr string
+	}{
+		{
+			name:    "duplicate ID",
+			cases:   []TestCase{recordingCase("a", TestStatusPass, &ran), recordingCase("a", TestStatusPass, &ran)},
+			wantErr: "duplicate test case ID",
+		},
+		{
+			name:    "unknown prerequisite",
+			cases:   []TestCase{recordingCase("a", TestStatusPass, &ran, "missing")},
+			wantErr: "prerequisite missing",
+		},
+		{
+			name:    "prerequisite declared later",
+			cases:   []TestCase{recordingCase("a", TestStatusPass, &ran, "b"), recordingCase("b", TestStatusPass, &ran)},
+			wantErr: "prerequisite b",
+		},
+		{
+			name:    "missing ID",
+			cases:   []TestCase{recordingCase("", TestStatusPass, &ran)},
+			wantErr: "without an ID",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRunner(newStubHarness(), time.Minute, time.Second)
+			err := r.RegisterModule(newCaseModule("m", tt.cases...))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("RegisterModule() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRun_TestCasesPrerequisites(t *testing.T) {
+	var ran []string
+	module := newCaseModule("m",
+		recordingCase("setup", TestStatusFail, &ran),
+		recordingCase("dependent", TestStatusPass, &ran, "setup"),
+		recordingCase("independent", TestStatusPass, &ran),
+	)
+
+	r := NewRunner(newStubHarness(), time.Minute, time.Second)
+	if err := r.RegisterModule(module); err != nil {
+		t.Fatalf("RegisterModule() unexpected error: %v", err)
+	}
+
+	suite, err := r.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+
+	if want := []string{"setup", "independent"}; !slices.Equal(ran, want) {
+		t.Errorf("executed cases = %v, want %v", ran, want)
+	}
+
+	wantStatus := map[string]TestStatus{
+		"m/setup":       TestStatusFail,
+		"m/dependent":   TestStatusSkip,
+		"m/independent": TestStatusPass,
+	}
+	if len(suite.Results) != len(wantStatus) {
+		t.Fatalf("Run() = %d results, want %d", len(suite.Results), len(wantStatus))
+	}
+	for _, result := range suite.Results {
+		if result.Status != wantStatus[result.TestID] {
+			t.Errorf("%s status = %s, want %s", result.TestID, result.Status, wantStatus[result.TestID])
+		}
+	}
+	if !strings.Contains(suite.Results[1].Message, "Prerequisite m/setup did not pass") {
+		t.Errorf("dependent message = %q, want prerequisite reason", suite.Results[1].Message)
+	}
+}
+
+func TestRun_SelectionIncludesPrerequisites(t *testing.T) {
+	var ran []string
+	module := newCaseModule("m",
+		recordingCase("a", TestStatusPass, &ran),
+		recordingCase("b", TestStatusPass, &ran, "a"),
+		recordingCase("c", TestStatusPass, &ran, "b"),
+		recordingCase("d", TestStatusPass, &ran),
+	)
+
+	r := NewRunner(newStubHarness(), time.Minute, time.Second)
+	r.SetSelectionPolicy(Selection{Targets: []string{"m/c"}})
+	if err := r.RegisterModule(module); err != nil {
+		t.Fatalf("RegisterModule() unexpected error: %v", err)
+	}
+
+	suite, err := r.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+
+	if want := []string{"a", "b", "c"}; !slices.Equal(ran, want) {
+		t.Errorf("executed cases = %v, want %v", ran, want)
+	}
+	if got := suite.Results[3].Status; got != TestStatusSkip {
+		t.Errorf("unselected case status = %s, want skip", got)
+	}
+}
+
+func TestRun_ForbiddenPrerequisiteNotPulledIn(t *testing.T) {
+	var ran []string
+	scan := recordingCase("scan", TestStatusPass, &ran)
+	scan.Tags = []string{TagActive}
+	module := newCaseModule("m",
+		scan,
+		recordingCase("analyze", TestStatusPass, &ran, "scan"),
+		recordingCase("other", TestStatusPass, &ran),
+	)
+
+	r := NewRunner(newStubHarness(), time.Minute, time.Second)
+	r.SetSelectionPolicy(Selection{
+		Targets:    []string{"m/analyze", "m/other"},
+		ForbidTags: map[string]string{TagActive: "scanning not allowed"},
+	})
+	if err := r.RegisterModule(module); err != nil {
+		t.Fatalf("RegisterModule() unexpected error: %v", err)
+	}
+
+	suite, err := r.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+
+	if want := []string{"other"}; !slices.Equal(ran, want) {
+		t.Errorf("executed cases = %v, want %v", ran, want)
+	}
+	if got := suite.Results[0].Message; got != "Test Test scan skipped: scanning not allowed" {
+		t.Errorf("forbidden case message = %q", got)
+	}
+	if got := suite.Results[1].Message; !strings.Contains(got, "Prerequisite m/scan did not pass") {
+		t.Errorf("dependent case message = %q, want prerequisite reason", got)
+	}
+}
+
+func TestRun_TestCasePanic(t *testing.T) {
+	var ran []string
+	module := newCaseModule("m",
+		TestCase{
+			ID:   "boom",
+			Name: "Boom",
+			Run: func(ctx context.Context, h agent.Harness) TestResult {
+				panic("kaboom")
+			},
+		},
+		recordingCase("after", TestStatusPass, &ran),
+	)
+
+	r := NewRunner(newStubHarness(), time.Minute, time.Second)
+	if err := r.RegisterModule(module); err != nil {
+		t.Fatalf("RegisterModule() unexpected error: %v", err)
+	}
+
+	suite, _ := r.Run(context.Background())
+	if len(suite.Results) != 2 {
+		t.Fatalf("Run() = %d results, want 2", len(suite.Results))
+	}
+	if suite.Results[0].Status != TestStatusError || !strings.Contains(suite.Results[0].Message, "kaboom") {
+		t.Errorf("panicking case = %s %q, want error with panic value", suite.Results[0].Status, suite.Results[0].Message)
+	}
+	if suite.Results[1].Status != TestStatusPass {
+		t.Errorf("case after panic status = %s, want pass", suite.Results[1].Status)
+	}
+}
+
+func TestListTests(t *testing.T) {
+	var ran []string
+	r := NewRunner(newStubHarness(), time.Minute, time.Second)
+	err := r.RegisterModules(
+		newCaseModule("m",
+			recordingCase("a", TestStatusPass, &ran),
+			recordingCase("b", TestStatusPass, &ran, "a"),
+		),
+		newFakeModule("plain"),
+	)
+	if err != nil {
+		t.Fatalf("RegisterModules() unexpected error: %v", err)
+	}
+
+	tests := r.ListTests()
+	var ids []string
+	for _, info := range tests {
+		ids = append(ids, info.ID)
+	}
+	if want := []string{"m/a", "m/b", "plain"}; !slices.Equal(ids, want) {
+		t.Errorf("ListTests() IDs = %v, want %v", ids, want)
+	}
+	if want := []string{"m/a"}; !slices.Equal(tests[1].Prerequisites, want) {
+		t.Errorf("ListTests()[1].Prerequisites = %v, want %v", tests[1].Prerequisites, want)
+	}
+}
+
+func TestRunTests_RerunsFailures(t *testing.T) {
+	var ran []string
+	r := NewRunner(newStubHarness(), time.Minute, time.Second)
+	err := r.RegisterModules(
+		newCaseModule("m",
+			recordingCase("a", TestStatusPass, &ran),
+			recordingCase("b", TestStatusFail, &ran, "a"),
+			recordingCase("c", TestStatusPass, &ran),
+		),
+		newFakeModule("other"),
+	)
+	if err != nil {
+		t.Fatalf("RegisterModules() unexpected error: %v", err)
+	}
+
+	suite, err := r.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+
+	failed := suite.FailedTestIDs()
+	if want := []string{"m/b"}; !slices.Equal(failed, want) {
+		t.Fatalf("FailedTestIDs() = %v, want %v", failed, want)
+	}
+
+	ran = nil
+	rerun, err := r.RunTests(context.Background(), failed...)
+	if err != nil {
+		t.Fatalf("RunTests() unexpected error: %v", err)
+	}
+	if want := []string{"a", "b"}; !slices.Equal(ran, want) {
+		t.Errorf("re-run executed cases = %v, want %v", ran, want)
+	}
+	for _, result := range rerun.Results {
+		if strings.HasPrefix(result.TestID, "other") {
+			t.Errorf("RunTests() ran unrequested module: %s", result.TestID)
+		}
+	}
+
+	if _, err := r.RunTests(context.Background(), "m/unknown"); err == nil {
+		t.Error("RunTests() expected error for unknown test ID")
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/zero-day-ai/sdk/agent"
 
+	"github.com/zero-day-ai/agents/debug/internal/assert"
 	"github.com/zero-day-ai/agents/debug/internal/runner"
 )
 
@@ -46,36 +47,29 @@ func (b BaseModule) RequirementID() string {
 type TestFunc func(ctx context.Context, h agent.Harness) runner.TestResult
 
 // RunTest executes a test function with timing and error recovery
+// A panic in the test function becomes an error result with the panic value and stack
 func RunTest(testName, requirementID string, testFunc TestFunc) TestFunc {
 	return func(ctx context.Context, h agent.Harness) runner.TestResult {
 		startTime := time.Now()
+		test := runner.TestRef{Name: testName, RequirementID: requirementID}
 
-		defer func() {
-			if r := recover(); r != nil {
-				// Panic recovery - return error result
-				h.Logger().Error("Test panicked",
-					"test", testName,
-					"panic", r,
-				)
-			}
-		}()
-
-		result := testFunc(ctx, h)
+		result := runner.RunGuarded(ctx, h, test, runner.CategorySDK, testFunc)
 		result.Duration = time.Since(startTime)
 		return result
 	}
 }
 
-// AssertEqual checks if two values are equal
-func AssertEqual(testName, requirementID string, expected, actual any, description string) runner.TestResult {
-	if expected != actual {
+// Check converts the outcome of an assert package assertion into a test result,
+// e.g. Check(name, reqID, assert.InDelta(0.9, score, 0.05), "confidence score")
+func Check(testName, requirementID string, err error, description string) runner.TestResult {
+	if err != nil {
 		return runner.NewFailResult(
 			testName,
 			requirementID,
 			runner.CategorySDK,
 			0,
-			fmt.Sprintf("%s: expected %v, got %v", description, expected, actual),
-			fmt.Errorf("assertion failed: %s", description),
+			fmt.Sprintf("%s: %v", description, err),
+			err,
 		)
 	}
 	return runner.NewPassResult(
@@ -83,53 +77,36 @@ func AssertEqual(testName, requirementID string, expected, actual any, descripti
 		requirementID,
 		runner.CategorySDK,
 		0,
-		fmt.Sprintf("%s: values match (%v)", description, actual),
+		fmt.Sprintf("%s: ok", description),
 	)
 }
 
+// check is Check with a specific pass message
+func check(testName, requirementID string, err error, description, passMessage string) runner.TestResult {
+	result := Check(testName, requirementID, err, description)
+	if err == nil {
+		result.Message = fmt.Sprintf("%s: %s", description, passMessage)
+	}
+	return result
+}
+
+// AssertEqual checks if two values are deeply equal, listing every differing field on failure
+func AssertEqual(testName, requirementID string, expected, actual any, description string) runner.TestResult {
+	return check(testName, requirementID, assert.Equal(expected, actual), description, fmt.Sprintf("values match (%v)", actual))
+}
+
 // AssertNotNil checks if a value is not nil
 func AssertNotNil(testName, requirementID string, value any, description string) runner.TestResult {
-	if value == nil {
-		return runner.NewFailResult(
-			testName,
-			requirementID,
-			runner.CategorySDK,
-			0,
-			fmt.Sprintf("%s: expected non-nil value, got nil", description),
-			fmt.Errorf("assertion failed: %s is nil", description),
-		)
-	}
-	return runner.NewPassResult(
-		testName,
-		requirementID,
-		runner.CategorySDK,
-		0,
-		fmt.Sprintf("%s: value is not nil", description),
-	)
+	return check(testName, requirementID, assert.NotNil(value), description, "value is not nil")
 }
 
 // AssertNil checks if a value is nil
 func AssertNil(testName, requirementID string, value any, description string) runner.TestResult {
-	if value != nil {
-		return runner.NewFailResult(
-			testName,
-			requirementID,
-			runner.CategorySDK,
-			0,
-			fmt.Sprintf("%s: expected nil, got %v", description, value),
-			fmt.Errorf("assertion failed: %s is not nil", description),
-		)
-	}
-	return runner.NewPassResult(
-		testName,
-		requirementID,
-		runner.CategorySDK,
-		0,
-		fmt.Sprintf("%s: value is nil", description),
-	)
+	return check(testName, requirementID, assert.Nil(value), description, "value is nil")
 }
 
 // AssertNoError checks if an error is nil
+// The failure carries err itself so harness and tool errors keep their kind
 func AssertNoError(testName, requirementID string, err error, description string) runner.TestResult {
 	if err != nil {
 		return runner.NewFailResult(
@@ -141,132 +118,35 @@ func AssertNoError(testName, requirementID string, err error, description string
 			err,
 		)
 	}
-	return runner.NewPassResult(
-		testName,
-		requirementID,
-		runner.CategorySDK,
-		0,
-		fmt.Sprintf("%s: no error", description),
-	)
+	return check(testName, requirementID, nil, description, "no error")
 }
 
 // AssertError checks if an error is not nil
 func AssertError(testName, requirementID string, err error, description string) runner.TestResult {
 	if err == nil {
-		return runner.NewFailResult(
-			testName,
-			requirementID,
-			runner.CategorySDK,
-			0,
-			fmt.Sprintf("%s: expected error, got nil", description),
-			fmt.Errorf("assertion failed: expected error but got nil"),
-		)
+		return Check(testName, requirementID, assert.True(false, "expected error, got nil"), description)
 	}
-	return runner.NewPassResult(
-		testName,
-		requirementID,
-		runner.CategorySDK,
-		0,
-		fmt.Sprintf("%s: error present: %v", description, err),
-	)
+	return check(testName, requirementID, nil, description, fmt.Sprintf("error present: %v", err))
 }
 
 // AssertTrue checks if a boolean value is true
 func AssertTrue(testName, requirementID string, value bool, description string) runner.TestResult {
-	if !value {
-		return runner.NewFailResult(
-			testName,
-			requirementID,
-			runner.CategorySDK,
-			0,
-			fmt.Sprintf("%s: expected true, got false", description),
-			fmt.Errorf("assertion failed: %s", description),
-		)
-	}
-	return runner.NewPassResult(
-		testName,
-		requirementID,
-		runner.CategorySDK,
-		0,
-		fmt.Sprintf("%s: condition is true", description),
-	)
+	return check(testName, requirementID, assert.True(value, "expected true, got false"), description, "condition is true")
 }
 
 // AssertFalse checks if a boolean value is false
 func AssertFalse(testName, requirementID string, value bool, description string) runner.TestResult {
-	if value {
-		return runner.NewFailResult(
-			testName,
-			requirementID,
-			runner.CategorySDK,
-			0,
-			fmt.Sprintf("%s: expected false, got true", description),
-			fmt.Errorf("assertion failed: %s", description),
-		)
-	}
-	return runner.NewPassResult(
-		testName,
-		requirementID,
-		runner.CategorySDK,
-		0,
-		fmt.Sprintf("%s: condition is false", description),
-	)
+	return check(testName, requirementID, assert.False(value, "expected false, got true"), description, "condition is false")
 }
 
 // AssertGreaterThan checks if a value is greater than another
 func AssertGreaterThan(testName, requirementID string, value, threshold int, description string) runner.TestResult {
-	if value <= threshold {
-		return runner.NewFailResult(
-			testName,
-			requirementID,
-			runner.CategorySDK,
-			0,
-			fmt.Sprintf("%s: expected > %d, got %d", description, threshold, value),
-			fmt.Errorf("assertion failed: %s", description),
-		)
-	}
-	return runner.NewPassResult(
-		testName,
-		requirementID,
-		runner.CategorySDK,
-		0,
-		fmt.Sprintf("%s: %d > %d", description, value, threshold),
-	)
+	return check(testName, requirementID, assert.Greater(value, threshold), description, fmt.Sprintf("%d > %d", value, threshold))
 }
 
 // AssertContains checks if a string contains a substring
 func AssertContains(testName, requirementID string, haystack, needle, description string) runner.TestResult {
-	if !contains(haystack, needle) {
-		return runner.NewFailResult(
-			testName,
-			requirementID,
-			runner.CategorySDK,
-			0,
-			fmt.Sprintf("%s: '%s' does not contain '%s'", description, haystack, needle),
-			fmt.Errorf("assertion failed: %s", description),
-		)
-	}
-	return runner.NewPassResult(
-		testName,
-		requirementID,
-		runner.CategorySDK,
-		0,
-		fmt.Sprintf("%s: string contains substring", description),
-	)
-}
-
-// contains is a helper to check if a string contains a substring
-func contains(s, substr string) bool {
-	return len(s) >= len(substr) && (s == substr || len(substr) == 0 || findSubstring(s, substr))
-}
-
-func findSubstring(s, substr string) bool {
-	for i := 0; i <= len(s)-len(substr); i++ {
-		if s[i:i+len(substr)] == substr {
-			return true
-		}
-	}
-	return false
+	return check(testName, requirementID, assert.Contains(haystack, needle), description, "string contains substring")
 }
 
 // CreateTestResult is a helper to create test results with consistent formatting
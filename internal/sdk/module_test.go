@@ -0,0 +1,72 @@
+package sdk
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/zero-day-ai/sdk/agent"
+
+	"github.com/zero-day-ai/agents/debug/internal/runner"
+	"github.com/zero-day-ai/agents/debug/internal/testutil"
+)
+
+func TestRunTest_Panic(t *testing.T) {
+	test := RunTest("Crashing Test", "SDK-1", func(ctx context.Context, h agent.Harness) runner.TestResult {
+		var analysis *HostAnalysis
+		return runner.NewPassResult("Crashing Test", "SDK-1", runner.CategorySDK, 0, analysis.Purpose)
+	})
+
+	result := test(context.Background(), testutil.LoggerHarness{})
+
+	if result.Status != runner.TestStatusError {
+		t.Fatalf("Status = %q, want error", result.Status)
+	}
+	if result.TestName != "Crashing Test" || result.RequirementID != "SDK-1" || result.Category != runner.CategorySDK {
+		t.Errorf("identity = %q/%q/%q, want the test's name, requirement and category",
+			result.TestName, result.RequirementID, result.Category)
+	}
+	if !strings.Contains(result.Message, "nil pointer dereference") {
+		t.Errorf("Message = %q, want panic value", result.Message)
+	}
+
+	testErr := runner.AsTestError(result.Error)
+	if testErr == nil || testErr.Kind != runner.ErrorKindPanic || !strings.Contains(testErr.Stack, "module_test.go") {
+		t.Errorf("error = %+v, want panic with stack", testErr)
+	}
+	if result.Duration <= 0 {
+		t.Errorf("Duration = %v, want measured duration", result.Duration)
+	}
+}
+
+func TestRunTest_EmptyResult(t *testing.T) {
+	test := RunTest("Forgetful Test", "SDK-2", func(ctx context.Context, h agent.Harness) runner.TestResult {
+		return runner.TestResult{}
+	})
+
+	result := test(context.Background(), testutil.LoggerHarness{})
+
+	if result.Status != runner.TestStatusError || result.TestName != "Forgetful Test" {
+		t.Errorf("result = %s %q, want error attributed to the test", result.Status, result.TestName)
+	}
+}
+
+func TestAssertEqual(t *testing.T) {
+	want := ScanResults{Hosts: []HostResult{{IP: "10.0.0.1", Status: "up"}}}
+	got := ScanResults{Hosts: []HostResult{{IP: "10.0.0.1", Status: "down"}}}
+
+	result := AssertEqual("Scan", "SDK-3", want, got, "scan results")
+	if result.Status != runner.TestStatusFail {
+		t.Fatalf("Status = %q, want failed", result.Status)
+	}
+	if !strings.Contains(result.Message, `.Hosts[0].Status: want "up", got "down"`) {
+		t.Errorf("Message = %q, want field diff", result.Message)
+	}
+	if testErr := runner.AsTestError(result.Error); testErr.Kind != runner.ErrorKindAssertion {
+		t.Errorf("Kind = %s, want assertion", testErr.Kind)
+	}
+
+	if result := AssertEqual("Scan", "SDK-3", want, want, "scan results"); result.Status != runner.TestStatusPass {
+		t.Errorf("Status = %q for equal values, want passed", result.Status)
+	}
+}
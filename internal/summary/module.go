@@ -0,0 +1,104 @@
+package summary
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/zero-day-ai/sdk/agent"
+
+	"github.com/zero-day-ai/agents/debug/internal/assert"
+	"github.com/zero-day-ai/agents/debug/internal/runner"
+)
+
+// requirementID is the requirement the summary report validates
+const requirementID = "REQ-32"
+
+// Module tests that published phase counts are aggregated into the summary report
+type Module struct{}
+
+// NewModule creates the summary report test module
+func NewModule() *Module {
+	return &Module{}
+}
+
+func (m *Module) Name() string {
+	return "summary"
+}
+
+func (m *Module) Description() string {
+	return "Aggregation of published phase results into the debug mission summary"
+}
+
+func (m *Module) Category() runner.Category {
+	return runner.CategorySDK
+}
+
+func (m *Module) RequirementID() string {
+	return requirementID
+}
+
+// TestCases returns the summary report test
+func (m *Module) TestCases() []runner.TestCase {
+	return []runner.TestCase{
+		{
+			ID:            "report",
+			Name:          "Summary Report Generation",
+			RequirementID: requirementID,
+			Tags:          []string{"summary", "memory"},
+			Run:           testReport,
+		},
+	}
+}
+
+// Run executes the summary report test
+func (m *Module) Run(ctx context.Context, h agent.Harness) []runner.TestResult {
+	return runner.RunCases(ctx, h, m, m.TestCases())
+}
+
+// testReport publishes counts for two [DEBUG] phases, summarizes them and checks
+// the totals and status. The phases use their own keys so the results of real
+// phases are left untouched.
+func testReport(ctx context.Context, h agent.Harness) runner.TestResult {
+	testName := "Summary Report Generation"
+	startTime := time.Now()
+
+	mem := h.Memory()
+	if mem == nil || mem.Working() == nil {
+		return runner.NewFailResult(testName, requirementID, runner.CategorySDK, 0,
+			"Working memory is not available", fmt.Errorf("memory store is nil"))
+	}
+	working := mem.Working()
+
+	phases := map[string]map[string]any{
+		"[DEBUG]_summary_passing": {"total": 3, "passed": 3, "failed": 0, "skipped": 0, "errors": 0},
+		"[DEBUG]_summary_failing": {"total": 2, "passed": 1, "failed": 1, "skipped": 0, "errors": 0},
+	}
+	keys := make([]string, 0, len(phases))
+	for key, counts := range phases {
+		if err := working.Set(ctx, key, counts); err != nil {
+			return runner.NewFailResult(testName, requirementID, runner.CategorySDK, time.Since(startTime),
+				fmt.Sprintf("Failed to publish phase %s: %v", key, err), err)
+		}
+		keys = append(keys, key)
+	}
+	runner.Cleanup(ctx, "working memory summary phases", func(ctx context.Context) error {
+		for _, key := range keys {
+			if err := working.Delete(ctx, key); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	summary := summarize(ctx, h, keys)
+
+	c := assert.NewChecker(testName, requirementID, runner.CategorySDK)
+	c.Check("phases found", assert.Equal(2, summary.TotalPhases))
+	c.Check("total", assert.Equal(5, summary.OverallTotal))
+	c.Check("passed", assert.Equal(4, summary.OverallPassed))
+	c.Check("failed", assert.Equal(1, summary.OverallFailed))
+	c.Check("status", assert.Equal("failed", summary.Status))
+	c.Check("report", assert.Contains(summary.Report, "[[DEBUG]_SUMMARY_FAILING]"))
+	return c.Result(time.Since(startTime), "Published phase counts aggregated into the summary report")
+}
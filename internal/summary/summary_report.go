@@ -12,29 +12,45 @@ import (
 
 // PhaseResults represents the pass/fail counts for a specific test phase
 type PhaseResults struct {
-	PhaseName string `json:"phase_name"`
-	Total     int    `json:"total"`
-	Passed    int    `json:"passed"`
-	Failed    int    `json:"failed"`
-	Skipped   int    `json:"skipped"`
-	Errors    int    `json:"errors"`
-	PassRate  float64 `json:"pass_rate"`
+	PhaseName      string  `json:"phase_name"`
+	Total          int     `json:"total"`
+	Passed         int     `json:"passed"`
+	Failed         int     `json:"failed"`
+	Skipped        int     `json:"skipped"`
+	Errors         int     `json:"errors"`
+	NotImplemented int     `json:"not_implemented"`
+	PassRate       float64 `json:"pass_rate"`
 }
 
 // DebugMissionSummary represents the overall summary of debug mission results
 type DebugMissionSummary struct {
-	GeneratedAt    time.Time      `json:"generated_at"`
-	MissionID      string         `json:"mission_id"`
-	TotalPhases    int            `json:"total_phases"`
-	PhaseResults   []PhaseResults `json:"phase_results"`
-	OverallTotal   int            `json:"overall_total"`
-	OverallPassed  int            `json:"overall_passed"`
-	OverallFailed  int            `json:"overall_failed"`
-	OverallSkipped int            `json:"overall_skipped"`
-	OverallErrors  int            `json:"overall_errors"`
-	OverallPassRate float64       `json:"overall_pass_rate"`
-	Status         string         `json:"status"`
-	Report         string         `json:"report"`
+	GeneratedAt           time.Time      `json:"generated_at"`
+	MissionID             string         `json:"mission_id"`
+	TotalPhases           int            `json:"total_phases"`
+	PhaseResults          []PhaseResults `json:"phase_results"`
+	OverallTotal          int            `json:"overall_total"`
+	OverallPassed         int            `json:"overall_passed"`
+	OverallFailed         int            `json:"overall_failed"`
+	OverallSkipped        int            `json:"overall_skipped"`
+	OverallErrors         int            `json:"overall_errors"`
+	OverallNotImplemented int            `json:"overall_not_implemented"`
+	OverallPassRate       float64        `json:"overall_pass_rate"`
+	Status                string         `json:"status"`
+	Report                string         `json:"report"`
+}
+
+// Phases are the working memory keys under which debug tasks publish their
+// pass/fail counts (total, passed, failed, skipped, errors)
+var Phases = []string{
+	"health_check",
+	"memory_test",
+	"tools_test",
+	"graphrag_test",
+	"llm_test",
+	"delegation_test",
+	"findings_test",
+	"sdk_tests",
+	"framework_tests",
 }
 
 // Config represents the configuration for summary generation
@@ -52,6 +68,64 @@ func ExecuteSummary(ctx context.Context, h agent.Harness, cfg *Config) (agent.Re
 
 	logger.Info("Summary generation started")
 
+	summary := summarize(ctx, h, Phases)
+
+	// Log the summary via harness
+	logger.Info("Debug mission summary generated",
+		"total_phases", summary.TotalPhases,
+		"overall_total", summary.OverallTotal,
+		"overall_passed", summary.OverallPassed,
+		"overall_failed", summary.OverallFailed,
+		"overall_status", summary.Status,
+		"pass_rate", fmt.Sprintf("%.2f%%", summary.OverallPassRate*100),
+	)
+
+	// Marshal to JSON
+	summaryJSON, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		logger.Error("Failed to marshal summary",
+			"error", err,
+		)
+		return agent.Result{
+			Status: agent.StatusFailed,
+			Output: fmt.Sprintf("Failed to marshal summary: %v", err),
+			Error:  err,
+		}, nil
+	}
+
+	duration := time.Since(startTime)
+	logger.Info("Summary generation completed",
+		"duration", duration,
+	)
+
+	// Determine result status
+	resultStatus := agent.StatusSuccess
+	if summary.Status == "error" || summary.Status == "failed" {
+		resultStatus = agent.StatusPartial
+	}
+
+	return agent.Result{
+		Status: resultStatus,
+		Output: string(summaryJSON),
+		Metadata: map[string]any{
+			"duration":                duration.String(),
+			"total_phases":            summary.TotalPhases,
+			"overall_total":           summary.OverallTotal,
+			"overall_passed":          summary.OverallPassed,
+			"overall_failed":          summary.OverallFailed,
+			"overall_errors":          summary.OverallErrors,
+			"overall_not_implemented": summary.OverallNotImplemented,
+			"overall_pass_rate":       summary.OverallPassRate,
+			"status":                  summary.Status,
+		},
+	}, nil
+}
+
+// summarize aggregates the counts stored under the given phase keys of working
+// memory, falling back to mission memory when none are found there
+func summarize(ctx context.Context, h agent.Harness, phases []string) *DebugMissionSummary {
+	logger := h.Logger()
+
 	// Initialize summary
 	summary := &DebugMissionSummary{
 		GeneratedAt:  time.Now(),
@@ -65,19 +139,6 @@ func ExecuteSummary(ctx context.Context, h agent.Harness, cfg *Config) (agent.Re
 
 	logger.Debug("Reading test results from working memory")
 
-	// Try to read various test phase results from memory
-	phases := []string{
-		"health_check",
-		"memory_test",
-		"tools_test",
-		"graphrag_test",
-		"llm_test",
-		"delegation_test",
-		"findings_test",
-		"sdk_tests",
-		"framework_tests",
-	}
-
 	for _, phase := range phases {
 		// Try to read results for this phase
 		data, err := workingMem.Get(ctx, phase)
@@ -136,11 +197,12 @@ func ExecuteSummary(ctx context.Context, h agent.Harness, cfg *Config) (agent.Re
 		summary.OverallFailed += phase.Failed
 		summary.OverallSkipped += phase.Skipped
 		summary.OverallErrors += phase.Errors
+		summary.OverallNotImplemented += phase.NotImplemented
 	}
 
-	// Calculate overall pass rate
-	if summary.OverallTotal > 0 {
-		summary.OverallPassRate = float64(summary.OverallPassed) / float64(summary.OverallTotal)
+	// Calculate overall pass rate, leaving out tests that are not implemented
+	if implemented := summary.OverallTotal - summary.OverallNotImplemented; implemented > 0 {
+		summary.OverallPassRate = float64(summary.OverallPassed) / float64(implemented)
 	}
 
 	// Determine overall status
@@ -150,7 +212,7 @@ func ExecuteSummary(ctx context.Context, h agent.Harness, cfg *Config) (agent.Re
 		summary.Status = "failed"
 	} else if summary.OverallTotal == 0 {
 		summary.Status = "no_tests"
-	} else if summary.OverallTotal == summary.OverallSkipped {
+	} else if summary.OverallTotal == summary.OverallSkipped+summary.OverallNotImplemented {
 		summary.Status = "skipped"
 	} else {
 		summary.Status = "success"
@@ -159,54 +221,7 @@ func ExecuteSummary(ctx context.Context, h agent.Harness, cfg *Config) (agent.Re
 	// Generate human-readable report
 	summary.Report = generateReport(summary)
 
-	// Log the summary via harness
-	logger.Info("Debug mission summary generated",
-		"total_phases", summary.TotalPhases,
-		"overall_total", summary.OverallTotal,
-		"overall_passed", summary.OverallPassed,
-		"overall_failed", summary.OverallFailed,
-		"overall_status", summary.Status,
-		"pass_rate", fmt.Sprintf("%.2f%%", summary.OverallPassRate*100),
-	)
-
-	// Marshal to JSON
-	summaryJSON, err := json.MarshalIndent(summary, "", "  ")
-	if err != nil {
-		logger.Error("Failed to marshal summary",
-			"error", err,
-		)
-		return agent.Result{
-			Status: agent.StatusFailed,
-			Output: fmt.Sprintf("Failed to marshal summary: %v", err),
-			Error:  err,
-		}, nil
-	}
-
-	duration := time.Since(startTime)
-	logger.Info("Summary generation completed",
-		"duration", duration,
-	)
-
-	// Determine result status
-	resultStatus := agent.StatusSuccess
-	if summary.Status == "error" || summary.Status == "failed" {
-		resultStatus = agent.StatusPartial
-	}
-
-	return agent.Result{
-		Status: resultStatus,
-		Output: string(summaryJSON),
-		Metadata: map[string]any{
-			"duration":          duration.String(),
-			"total_phases":      summary.TotalPhases,
-			"overall_total":     summary.OverallTotal,
-			"overall_passed":    summary.OverallPassed,
-			"overall_failed":    summary.OverallFailed,
-			"overall_errors":    summary.OverallErrors,
-			"overall_pass_rate": summary.OverallPassRate,
-			"status":            summary.Status,
-		},
-	}, nil
+	return summary
 }
 
 // parsePhaseResults attempts to parse test results from memory data
@@ -218,25 +233,28 @@ func parsePhaseResults(phaseName string, data any) (PhaseResults, error) {
 	// Try to parse as JSON map
 	if dataMap, ok := data.(map[string]any); ok {
 		// Extract counts from common field names
-		if total, ok := dataMap["total"].(float64); ok {
-			result.Total = int(total)
+		if total, ok := count(dataMap["total"]); ok {
+			result.Total = total
+		}
+		if passed, ok := count(dataMap["passed"]); ok {
+			result.Passed = passed
 		}
-		if passed, ok := dataMap["passed"].(float64); ok {
-			result.Passed = int(passed)
+		if failed, ok := count(dataMap["failed"]); ok {
+			result.Failed = failed
 		}
-		if failed, ok := dataMap["failed"].(float64); ok {
-			result.Failed = int(failed)
+		if skipped, ok := count(dataMap["skipped"]); ok {
+			result.Skipped = skipped
 		}
-		if skipped, ok := dataMap["skipped"].(float64); ok {
-			result.Skipped = int(skipped)
+		if errors, ok := count(dataMap["errors"]); ok {
+			result.Errors = errors
 		}
-		if errors, ok := dataMap["errors"].(float64); ok {
-			result.Errors = int(errors)
+		if notImplemented, ok := count(dataMap["not_implemented"]); ok {
+			result.NotImplemented = notImplemented
 		}
 
-		// Calculate pass rate
-		if result.Total > 0 {
-			result.PassRate = float64(result.Passed) / float64(result.Total)
+		// Calculate pass rate, leaving out tests that are not implemented
+		if implemented := result.Total - result.NotImplemented; implemented > 0 {
+			result.PassRate = float64(result.Passed) / float64(implemented)
 		}
 
 		return result, nil
@@ -250,6 +268,18 @@ func parsePhaseResults(phaseName string, data any) (PhaseResults, error) {
 	return result, nil
 }
 
+// count converts a count read from memory, which is an int when stored by this
+// process and a float64 after a JSON round-trip
+func count(value any) (int, bool) {
+	switch n := value.(type) {
+	case int:
+		return n, true
+	case float64:
+		return int(n), true
+	}
+	return 0, false
+}
+
 // generateReport creates a human-readable summary report
 func generateReport(summary *DebugMissionSummary) string {
 	var builder strings.Builder
@@ -265,7 +295,8 @@ func generateReport(summary *DebugMissionSummary) string {
 	builder.WriteString(fmt.Sprintf("Passed: %d (%.1f%%)\n", summary.OverallPassed, summary.OverallPassRate*100))
 	builder.WriteString(fmt.Sprintf("Failed: %d\n", summary.OverallFailed))
 	builder.WriteString(fmt.Sprintf("Skipped: %d\n", summary.OverallSkipped))
-	builder.WriteString(fmt.Sprintf("Errors: %d\n\n", summary.OverallErrors))
+	builder.WriteString(fmt.Sprintf("Errors: %d\n", summary.OverallErrors))
+	builder.WriteString(fmt.Sprintf("Not Implemented: %d\n\n", summary.OverallNotImplemented))
 
 	if len(summary.PhaseResults) > 0 {
 		builder.WriteString("=== Phase Breakdown ===\n")
@@ -276,6 +307,7 @@ func generateReport(summary *DebugMissionSummary) string {
 			builder.WriteString(fmt.Sprintf("  Failed: %d\n", phase.Failed))
 			builder.WriteString(fmt.Sprintf("  Skipped: %d\n", phase.Skipped))
 			builder.WriteString(fmt.Sprintf("  Errors: %d\n", phase.Errors))
+			builder.WriteString(fmt.Sprintf("  Not Implemented: %d\n", phase.NotImplemented))
 		}
 	} else {
 		builder.WriteString("=== No Phase Results Available ===\n")
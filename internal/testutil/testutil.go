@@ -0,0 +1,37 @@
+// Package testutil holds fixtures shared by the test module tests
+package testutil
+
+import (
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/zero-day-ai/sdk/agent"
+
+	"github.com/zero-day-ai/agents/debug/internal/runner"
+)
+
+// LoggerHarness satisfies agent.Harness with only a logger; any other harness
+// call panics
+type LoggerHarness struct {
+	agent.Harness
+}
+
+// Logger returns a logger discarding its output
+func (LoggerHarness) Logger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+// CheckResults reports an error for every result that does not have the wanted
+// status, and a fatal error when there are not count results
+func CheckResults(t testing.TB, results []runner.TestResult, count int, want runner.TestStatus) {
+	t.Helper()
+	if len(results) != count {
+		t.Fatalf("got %d results, want %d", len(results), count)
+	}
+	for _, result := range results {
+		if result.Status != want {
+			t.Errorf("%s: %s %q (%v), want %s", result.TestName, result.Status, result.Message, result.Error, want)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/zero-day-ai/sdk/agent"
+
+	"github.com/zero-day-ai/agents/debug/internal/runner"
+)
+
+// Module tests tool discovery and the execution of a safe tool
+type Module struct{}
+
+// NewModule creates the tool execution test module
+func NewModule() *Module {
+	return &Module{}
+}
+
+func (m *Module) Name() string {
+	return "tools"
+}
+
+func (m *Module) Description() string {
+	return "Tool discovery and execution of a safe tool (ping localhost, echo, list)"
+}
+
+func (m *Module) Category() runner.Category {
+	return runner.CategorySDK
+}
+
+func (m *Module) RequirementID() string {
+	return "REQ-3"
+}
+
+// TestCases returns the tool execution test
+func (m *Module) TestCases() []runner.TestCase {
+	return []runner.TestCase{
+		{
+			ID:            "execute",
+			Name:          "Tool Execution Test",
+			RequirementID: m.RequirementID(),
+			Tags:          []string{"tools"},
+			Run: func(ctx context.Context, h agent.Harness) runner.TestResult {
+				return ExecuteToolTest(ctx, h, TestConfig{})
+			},
+		},
+	}
+}
+
+// Run executes the tool test
+func (m *Module) Run(ctx context.Context, h agent.Harness) []runner.TestResult {
+	return runner.RunCases(ctx, h, m, m.TestCases())
+}
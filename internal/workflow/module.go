@@ -0,0 +1,90 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/zero-day-ai/sdk/agent"
+
+	"github.com/zero-day-ai/agents/debug/internal/runner"
+)
+
+// requirementID is the requirement the mission validation covers
+const requirementID = "19"
+
+// Mission is a mission file checked by the module
+type Mission struct {
+	// Name is the file name, used in test IDs and problem locations
+	Name string
+	Data []byte
+}
+
+// Module validates mission workflow files, one test per file
+type Module struct {
+	missions []Mission
+	opts     Options
+}
+
+// NewModule creates the mission validation module
+func NewModule(missions []Mission, opts Options) *Module {
+	return &Module{missions: missions, opts: opts}
+}
+
+func (m *Module) Name() string {
+	return "workflow"
+}
+
+func (m *Module) Description() string {
+	return "Mission workflow YAML: nodes DAG, timeouts, retry blocks, agents and task contexts"
+}
+
+func (m *Module) Category() runner.Category {
+	return runner.CategoryFramework
+}
+
+func (m *Module) RequirementID() string {
+	return requirementID
+}
+
+// TestCases returns one validation test per mission file
+func (m *Module) TestCases() []runner.TestCase {
+	cases := make([]runner.TestCase, 0, len(m.missions))
+	for _, mission := range m.missions {
+		base := path.Base(mission.Name)
+		testName := fmt.Sprintf("Workflow Validation (%s)", base)
+		cases = append(cases, runner.TestCase{
+			ID:            strings.TrimSuffix(base, path.Ext(base)),
+			Name:          testName,
+			RequirementID: requirementID,
+			Tags:          []string{"workflow"},
+			Run: func(ctx context.Context, h agent.Harness) runner.TestResult {
+				startTime := time.Now()
+				problems := Validate(mission.Data, m.opts)
+				if len(problems) == 0 {
+					return runner.NewPassResult(testName, requirementID, runner.CategoryFramework, time.Since(startTime),
+						fmt.Sprintf("Mission file %s is valid", mission.Name))
+				}
+
+				lines := make([]string, len(problems))
+				for i, p := range problems {
+					lines[i] = mission.Name + ":" + p.String()
+				}
+				message := fmt.Sprintf("%d problems in mission file %s", len(problems), mission.Name)
+				if len(problems) == 1 {
+					message = fmt.Sprintf("1 problem in mission file %s", mission.Name)
+				}
+				return runner.NewFailResult(testName, requirementID, runner.CategoryFramework, time.Since(startTime),
+					message, fmt.Errorf("%s", strings.Join(lines, "\n"))).WithDetails(map[string]any{"problems": lines})
+			},
+		})
+	}
+	return cases
+}
+
+// Run executes the mission validation tests
+func (m *Module) Run(ctx context.Context, h agent.Harness) []runner.TestResult {
+	return runner.RunCases(ctx, h, m, m.TestCases())
+}
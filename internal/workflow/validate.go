@@ -0,0 +1,471 @@
+// Package workflow validates mission workflow YAML before it reaches the
+// framework: the nodes DAG, node timeouts and retry blocks, the agents nodes
+// reference and the task context each agent receives. Problems are located by
+// line and column in the mission file.
+package workflow
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Problem is one error in a mission file. Line and Column are 1-based, or 0
+// when the position is unknown.
+type Problem struct {
+	Line    int
+	Column  int
+	Message string
+}
+
+// String formats the problem as "line:column: message", leaving out the
+// parts of the position that are unknown
+func (p Problem) String() string {
+	switch {
+	case p.Line == 0:
+		return p.Message
+	case p.Column == 0:
+		return fmt.Sprintf("%d: %s", p.Line, p.Message)
+	}
+	return fmt.Sprintf("%d:%d: %s", p.Line, p.Column, p.Message)
+}
+
+// ContextProblem is one error in a task context, located by its path below the
+// context ("mode", "tests[2]"); an empty path refers to the context as a whole
+type ContextProblem struct {
+	Path    string
+	Message string
+}
+
+// ContextValidator checks the task context of a node run by one agent
+type ContextValidator func(context map[string]any) []ContextProblem
+
+// Options configures Validate
+type Options struct {
+	// Agents maps the agent names nodes may reference to the validator of their
+	// task context; a nil validator leaves the context unchecked. A nil map
+	// accepts any agent.
+	Agents map[string]ContextValidator
+}
+
+// backoffs lists the accepted retry backoff strategies
+var backoffs = []string{"constant", "linear", "exponential"}
+
+// nodeKeys lists the keys of a node
+var nodeKeys = []string{"id", "type", "name", "description", "agent", "depends_on", "timeout", "retry", "task"}
+
+// retryKeys lists the keys of a retry block
+var retryKeys = []string{"max_retries", "backoff", "initial_delay", "max_delay", "multiplier"}
+
+// syntaxErrorPattern matches the line of a yaml.v3 syntax error
+var syntaxErrorPattern = regexp.MustCompile(`^yaml: line (\d+): (.*)$`)
+
+// Validate parses a mission file and returns its problems ordered by position
+func Validate(data []byte, opts Options) []Problem {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		if m := syntaxErrorPattern.FindStringSubmatch(err.Error()); m != nil {
+			line, _ := strconv.Atoi(m[1])
+			return []Problem{{Line: line, Message: "syntax error: " + m[2]}}
+		}
+		return []Problem{{Message: err.Error()}}
+	}
+	if len(doc.Content) == 0 {
+		return []Problem{{Line: 1, Column: 1, Message: "mission file is empty"}}
+	}
+
+	v := &validator{opts: opts, ids: map[string]*node{}}
+	v.mission(doc.Content[0])
+	sort.SliceStable(v.problems, func(i, j int) bool {
+		a, b := v.problems[i], v.problems[j]
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return v.problems
+}
+
+// validator collects the problems of one mission file
+type validator struct {
+	opts     Options
+	problems []Problem
+
+	nodes []*node
+	ids   map[string]*node
+}
+
+// node is a workflow node with a valid ID
+type node struct {
+	id   string
+	yaml *yaml.Node
+	deps []dependency
+}
+
+// dependency is one depends_on entry of a node
+type dependency struct {
+	id   string
+	yaml *yaml.Node
+}
+
+// errorf records a problem at the position of n
+func (v *validator) errorf(n *yaml.Node, format string, args ...any) {
+	v.problems = append(v.problems, Problem{Line: n.Line, Column: n.Column, Message: fmt.Sprintf(format, args...)})
+}
+
+// lookup returns the key and value nodes of key in mapping m (nil when absent)
+func lookup(m *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i], m.Content[i+1]
+		}
+	}
+	return nil, nil
+}
+
+// mapping checks that n is a mapping
+func (v *validator) mapping(n *yaml.Node, what string) bool {
+	if n.Kind != yaml.MappingNode {
+		v.errorf(n, "%s must be a mapping", what)
+		return false
+	}
+	return true
+}
+
+// str returns the value of a string scalar
+func (v *validator) str(n *yaml.Node, what string) (string, bool) {
+	if n.Kind != yaml.ScalarNode || n.Tag != "!!str" || n.Value == "" {
+		v.errorf(n, "%s must be a non-empty string", what)
+		return "", false
+	}
+	return n.Value, true
+}
+
+// duration returns the value of a positive duration scalar
+func (v *validator) duration(n *yaml.Node, what string) (time.Duration, bool) {
+	d, err := time.ParseDuration(n.Value)
+	if n.Kind != yaml.ScalarNode || err != nil {
+		v.errorf(n, "%s must be a duration such as \"90s\" or \"10m\", got %q", what, n.Value)
+		return 0, false
+	}
+	if d <= 0 {
+		v.errorf(n, "%s must be positive, got %s", what, n.Value)
+		return 0, false
+	}
+	return d, true
+}
+
+// mission checks the top-level mapping
+func (v *validator) mission(root *yaml.Node) {
+	if !v.mapping(root, "mission") {
+		return
+	}
+
+	if key, value := lookup(root, "name"); key == nil {
+		v.errorf(root, "mission has no name")
+	} else {
+		v.str(value, "name")
+	}
+
+	if _, bounds := lookup(root, "bounds"); bounds != nil && v.mapping(bounds, "bounds") {
+		if _, maxDuration := lookup(bounds, "max_duration"); maxDuration != nil {
+			v.duration(maxDuration, "bounds.max_duration")
+		}
+	}
+
+	key, nodes := lookup(root, "nodes")
+	switch {
+	case key == nil:
+		v.errorf(root, "mission has no nodes")
+		return
+	case nodes.Kind != yaml.SequenceNode:
+		v.errorf(nodes, "nodes must be a list")
+		return
+	case len(nodes.Content) == 0:
+		v.errorf(nodes, "nodes is empty")
+		return
+	}
+
+	for _, n := range nodes.Content {
+		v.node(n)
+	}
+	v.graph()
+}
+
+// node checks one entry of nodes and records it for the graph checks
+func (v *validator) node(n *yaml.Node) {
+	if !v.mapping(n, "node") {
+		return
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if key := n.Content[i]; !slices.Contains(nodeKeys, key.Value) {
+			v.errorf(key, "unknown node key %q (expected one of %s)", key.Value, strings.Join(nodeKeys, ", "))
+		}
+	}
+
+	var id string
+	if key, value := lookup(n, "id"); key == nil {
+		v.errorf(n, "node has no id")
+	} else if s, ok := v.str(value, "id"); ok {
+		if first, dup := v.ids[s]; dup {
+			v.errorf(value, "duplicate node id %q (first defined on line %d)", s, first.yaml.Line)
+		} else {
+			id = s
+		}
+	}
+	label := "node"
+	if id != "" {
+		label = fmt.Sprintf("node %q", id)
+	}
+
+	var agent string
+	var agentOK bool
+	if key, value := lookup(n, "type"); key == nil {
+		v.errorf(n, "%s has no type", label)
+	} else if nodeType, ok := v.str(value, "type"); ok && nodeType == "agent" {
+		agent, agentOK = v.agent(n, label)
+	}
+
+	var deps []dependency
+	if _, dependsOn := lookup(n, "depends_on"); dependsOn != nil {
+		if dependsOn.Kind != yaml.SequenceNode {
+			v.errorf(dependsOn, "depends_on must be a list of node ids")
+		} else {
+			for _, dep := range dependsOn.Content {
+				if s, ok := v.str(dep, "depends_on entry"); ok {
+					deps = append(deps, dependency{id: s, yaml: dep})
+				}
+			}
+		}
+	}
+
+	if _, timeout := lookup(n, "timeout"); timeout != nil {
+		v.duration(timeout, "timeout")
+	}
+	if _, retry := lookup(n, "retry"); retry != nil {
+		v.retry(retry)
+	}
+	if _, task := lookup(n, "task"); task != nil && v.mapping(task, "task") {
+		if _, context := lookup(task, "context"); context != nil && agentOK {
+			v.context(context, agent)
+		}
+	}
+
+	if id != "" {
+		entry := &node{id: id, yaml: n, deps: deps}
+		v.ids[id] = entry
+		v.nodes = append(v.nodes, entry)
+	}
+}
+
+// agent checks the agent an agent node references and reports whether it is known
+func (v *validator) agent(n *yaml.Node, label string) (string, bool) {
+	key, value := lookup(n, "agent")
+	if key == nil {
+		v.errorf(n, "agent %s has no agent", label)
+		return "", false
+	}
+	agent, ok := v.str(value, "agent")
+	if !ok {
+		return "", false
+	}
+	if v.opts.Agents == nil {
+		return agent, true
+	}
+	if _, known := v.opts.Agents[agent]; !known {
+		names := make([]string, 0, len(v.opts.Agents))
+		for name := range v.opts.Agents {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		v.errorf(value, "unknown agent %q (known: %s)", agent, strings.Join(names, ", "))
+		return "", false
+	}
+	return agent, true
+}
+
+// retry checks a retry block
+func (v *validator) retry(n *yaml.Node) {
+	if !v.mapping(n, "retry") {
+		return
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if key := n.Content[i]; !slices.Contains(retryKeys, key.Value) {
+			v.errorf(key, "unknown retry key %q (expected one of %s)", key.Value, strings.Join(retryKeys, ", "))
+		}
+	}
+
+	if _, maxRetries := lookup(n, "max_retries"); maxRetries != nil {
+		if count, err := strconv.Atoi(maxRetries.Value); maxRetries.Tag != "!!int" || err != nil || count < 0 {
+			v.errorf(maxRetries, "max_retries must be a non-negative integer, got %q", maxRetries.Value)
+		}
+	}
+	var backoff string
+	if _, value := lookup(n, "backoff"); value != nil {
+		if backoff = value.Value; !slices.Contains(backoffs, backoff) {
+			v.errorf(value, "backoff must be one of %s, got %q", strings.Join(backoffs, ", "), backoff)
+		}
+	}
+
+	var initialDelay time.Duration
+	if _, value := lookup(n, "initial_delay"); value != nil {
+		initialDelay, _ = v.duration(value, "initial_delay")
+	}
+	if _, value := lookup(n, "max_delay"); value != nil {
+		if maxDelay, ok := v.duration(value, "max_delay"); ok && maxDelay < initialDelay {
+			v.errorf(value, "max_delay %s is shorter than initial_delay %s", maxDelay, initialDelay)
+		}
+	}
+
+	if _, value := lookup(n, "multiplier"); value != nil {
+		multiplier, err := strconv.ParseFloat(value.Value, 64)
+		switch {
+		case (value.Tag != "!!int" && value.Tag != "!!float") || err != nil:
+			v.errorf(value, "multiplier must be a number, got %q", value.Value)
+		case multiplier < 1:
+			v.errorf(value, "multiplier must be at least 1, got %s", value.Value)
+		case backoff != "exponential":
+			v.errorf(value, "multiplier only applies to exponential backoff")
+		}
+	}
+}
+
+// context checks a task context with the validator of agent
+func (v *validator) context(n *yaml.Node, agent string) {
+	if !v.mapping(n, "task context") {
+		return
+	}
+	check := v.opts.Agents[agent]
+	if check == nil {
+		return
+	}
+
+	var values map[string]any
+	if err := n.Decode(&values); err != nil {
+		v.errorf(n, "task context: %v", err)
+		return
+	}
+	for _, p := range check(values) {
+		at := locate(n, p.Path)
+		if p.Path == "" {
+			v.errorf(at, "task context: %s", p.Message)
+		} else {
+			v.errorf(at, "task context %s: %s", p.Path, p.Message)
+		}
+	}
+}
+
+// locate returns the node a context path refers to: the key of "mode", the
+// item of "tests[2]", or the context itself when the path is empty or missing
+func locate(context *yaml.Node, path string) *yaml.Node {
+	key, index, hasIndex := strings.Cut(path, "[")
+	keyNode, value := lookup(context, key)
+	if keyNode == nil {
+		return context
+	}
+	if hasIndex && value.Kind == yaml.SequenceNode {
+		if i, err := strconv.Atoi(strings.TrimSuffix(index, "]")); err == nil && i >= 0 && i < len(value.Content) {
+			return value.Content[i]
+		}
+	}
+	return keyNode
+}
+
+// graph checks the depends_on edges: unknown nodes, cycles, and nodes that can
+// never run because something they depend on cannot
+func (v *validator) graph() {
+	for _, n := range v.nodes {
+		for _, dep := range n.deps {
+			if _, ok := v.ids[dep.id]; !ok {
+				v.errorf(dep.yaml, "node %q depends on unknown node %q", n.id, dep.id)
+			}
+		}
+	}
+
+	onCycle := v.cycles()
+
+	// A node can run once every node it depends on can
+	runnable := map[string]bool{}
+	for changed := true; changed; {
+		changed = false
+		for _, n := range v.nodes {
+			if runnable[n.id] {
+				continue
+			}
+			ready := true
+			for _, dep := range n.deps {
+				ready = ready && runnable[dep.id]
+			}
+			if ready {
+				runnable[n.id] = true
+				changed = true
+			}
+		}
+	}
+
+	for _, n := range v.nodes {
+		if runnable[n.id] || onCycle[n.id] {
+			continue
+		}
+		for _, dep := range n.deps {
+			if _, known := v.ids[dep.id]; known && !runnable[dep.id] {
+				v.errorf(dep.yaml, "node %q is unreachable: it depends on %q, which can never run", n.id, dep.id)
+				break
+			}
+		}
+	}
+}
+
+// cycles reports every dependency cycle once, at the depends_on entry closing
+// it, and returns the nodes on a cycle
+func (v *validator) cycles() map[string]bool {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := map[string]int{}
+	onCycle := map[string]bool{}
+	var stack []*node
+
+	var visit func(n *node)
+	visit = func(n *node) {
+		state[n.id] = visiting
+		stack = append(stack, n)
+		for _, dep := range n.deps {
+			target, ok := v.ids[dep.id]
+			if !ok {
+				continue
+			}
+			switch state[dep.id] {
+			case unvisited:
+				visit(target)
+			case visiting:
+				// The stack holds each node above the node it depends on
+				start := slices.IndexFunc(stack, func(s *node) bool { return s.id == dep.id })
+				var cycle []string
+				for _, s := range stack[start:] {
+					cycle = append(cycle, s.id)
+					onCycle[s.id] = true
+				}
+				cycle = append(cycle, dep.id)
+				v.errorf(dep.yaml, "dependency cycle: %s", strings.Join(cycle, " -> "))
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[n.id] = done
+	}
+
+	for _, n := range v.nodes {
+		if state[n.id] == unvisited {
+			visit(n)
+		}
+	}
+	return onCycle
+}
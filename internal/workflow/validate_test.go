@@ -0,0 +1,252 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/zero-day-ai/agents/debug/internal/runner"
+	"github.com/zero-day-ai/agents/debug/internal/testutil"
+)
+
+// testOptions knows debug-agent, whose contexts may only set mode and tests
+var testOptions = Options{Agents: map[string]ContextValidator{
+	"debug-agent": func(values map[string]any) []ContextProblem {
+		var problems []ContextProblem
+		for key, value := range values {
+			switch key {
+			case "mode":
+			case "tests":
+				items, _ := value.([]any)
+				for i, item := range items {
+					if item == "" {
+						problems = append(problems, ContextProblem{Path: fmt.Sprintf("tests[%d]", i), Message: "must not be empty"})
+					}
+				}
+			default:
+				problems = append(problems, ContextProblem{Path: key, Message: "unknown key"})
+			}
+		}
+		return problems
+	},
+	"other-agent": nil,
+}}
+
+// mission joins lines into a mission file
+func mission(lines ...string) []byte {
+	return []byte(strings.Join(lines, "\n") + "\n")
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want []string
+	}{
+		{
+			name: "valid",
+			data: mission(
+				"name: ok",
+				"nodes:",
+				"  - id: a",
+				"    type: agent",
+				"    agent: debug-agent",
+				"    timeout: 5m",
+				"    retry:",
+				"      max_retries: 3",
+				"      backoff: exponential",
+				"      initial_delay: 1s",
+				"      max_delay: 30s",
+				"      multiplier: 2",
+				"    task:",
+				"      context:",
+				"        mode: health-check",
+				"  - id: b",
+				"    type: agent",
+				"    agent: other-agent",
+				"    depends_on: [a]",
+			),
+		},
+		{
+			name: "empty",
+			data: []byte(""),
+			want: []string{"1:1: mission file is empty"},
+		},
+		{
+			name: "syntax error",
+			data: mission(
+				"name: broken",
+				"nodes:",
+				"  - id: a",
+				"    type: agent: scanner",
+			),
+			want: []string{"4: syntax error: mapping values are not allowed in this context"},
+		},
+		{
+			name: "missing name and nodes",
+			data: mission("description: nothing"),
+			want: []string{"1:1: mission has no name", "1:1: mission has no nodes"},
+		},
+		{
+			name: "duplicate id",
+			data: mission(
+				"name: dup",
+				"nodes:",
+				"  - id: a",
+				"    type: agent",
+				"    agent: debug-agent",
+				"  - id: a",
+				"    type: agent",
+				"    agent: debug-agent",
+			),
+			want: []string{`6:9: duplicate node id "a" (first defined on line 3)`},
+		},
+		{
+			name: "unknown dependency",
+			data: mission(
+				"name: deps",
+				"nodes:",
+				"  - id: a",
+				"    type: agent",
+				"    agent: debug-agent",
+				"    depends_on: [a2]",
+			),
+			want: []string{`6:18: node "a" depends on unknown node "a2"`},
+		},
+		{
+			name: "cycle and unreachable",
+			data: mission(
+				"name: cycle",
+				"nodes:",
+				"  - id: a",
+				"    type: agent",
+				"    agent: debug-agent",
+				"    depends_on: [b]",
+				"  - id: b",
+				"    type: agent",
+				"    agent: debug-agent",
+				"    depends_on: [a]",
+				"  - id: c",
+				"    type: agent",
+				"    agent: debug-agent",
+				"    depends_on: [b]",
+			),
+			want: []string{
+				"10:18: dependency cycle: a -> b -> a",
+				`14:18: node "c" is unreachable: it depends on "b", which can never run`,
+			},
+		},
+		{
+			name: "unknown node keys",
+			data: mission(
+				"name: typos",
+				"nodes:",
+				"  - id: a",
+				"    type: agent",
+				"    agent: debug-agent",
+				"  - id: b",
+				"    type: agent",
+				"    agent: debug-agent",
+				"    depend_on: [a]",
+				"    retries:",
+				"      max_retries: 2",
+			),
+			want: []string{
+				`9:5: unknown node key "depend_on" (expected one of id, type, name, description, agent, depends_on, timeout, retry, task)`,
+				`10:5: unknown node key "retries" (expected one of id, type, name, description, agent, depends_on, timeout, retry, task)`,
+			},
+		},
+		{
+			name: "timeout and retry",
+			data: mission(
+				"name: retry",
+				"nodes:",
+				"  - id: a",
+				"    type: agent",
+				"    agent: debug-agent",
+				"    timeout: soon",
+				"    retry:",
+				"      max_retries: -1",
+				"      backoff: linear",
+				"      initial_delay: 10s",
+				"      max_delay: 1s",
+				"      multiplier: 2",
+				"      jitter: true",
+			),
+			want: []string{
+				`6:14: timeout must be a duration such as "90s" or "10m", got "soon"`,
+				"8:20: max_retries must be a non-negative integer, got \"-1\"",
+				"11:18: max_delay 1s is shorter than initial_delay 10s",
+				"12:19: multiplier only applies to exponential backoff",
+				`13:7: unknown retry key "jitter" (expected one of max_retries, backoff, initial_delay, max_delay, multiplier)`,
+			},
+		},
+		{
+			name: "unknown agent",
+			data: mission(
+				"name: agents",
+				"nodes:",
+				"  - id: a",
+				"    type: agent",
+				"    agent: debug-agnet",
+			),
+			want: []string{`5:12: unknown agent "debug-agnet" (known: debug-agent, other-agent)`},
+		},
+		{
+			name: "task context",
+			data: mission(
+				"name: context",
+				"nodes:",
+				"  - id: a",
+				"    type: agent",
+				"    agent: debug-agent",
+				"    task:",
+				"      context:",
+				"        mode: single",
+				"        tests:",
+				"          - health",
+				"          - \"\"",
+				"        colour: blue",
+			),
+			want: []string{
+				"11:13: task context tests[1]: must not be empty",
+				"12:9: task context colour: unknown key",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, p := range Validate(tt.data, testOptions) {
+				got = append(got, p.String())
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("problems:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestModule_Run(t *testing.T) {
+	missions := []Mission{
+		{Name: "testdata/good-mission.yaml", Data: mission("name: good", "nodes:", "  - id: a", "    type: agent", "    agent: debug-agent")},
+		{Name: "testdata/bad-mission.yaml", Data: mission("name: bad", "nodes: []")},
+	}
+	results := NewModule(missions, testOptions).Run(context.Background(), testutil.LoggerHarness{})
+
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2", len(results))
+	}
+	if results[0].Status != runner.TestStatusPass {
+		t.Errorf("good mission: %s %q (%v), want passed", results[0].Status, results[0].Message, results[0].Error)
+	}
+	if results[1].Status != runner.TestStatusFail {
+		t.Errorf("bad mission: %s %q, want failed", results[1].Status, results[1].Message)
+	}
+	if results[1].Error == nil || !strings.Contains(results[1].Error.Error(), "testdata/bad-mission.yaml:2:") {
+		t.Errorf("bad mission error %v does not locate the problem", results[1].Error)
+	}
+}
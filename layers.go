@@ -0,0 +1,265 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// configFileEnv names the environment variable holding the config file path
+	// when --config is not given
+	configFileEnv = "DEBUG_AGENT_CONFIG"
+
+	// configEnvPrefix prefixes the environment variables that set configuration
+	// keys, e.g. DEBUG_AGENT_TEST_TIMEOUT sets test_timeout
+	configEnvPrefix = "DEBUG_AGENT_"
+)
+
+// Sources reported for each effective configuration value
+const (
+	sourceDefault   = "default"
+	sourceEnv       = "env"
+	sourceFlag      = "flag"
+	sourceMetadata  = "metadata"
+	sourceContext   = "context"
+	sourceGenerated = "generated"
+)
+
+// configLayer is one source of configuration values. Layers are applied in
+// order, each overriding the keys set by the ones before it: defaults, profile,
+// config file, environment, command line flags, task metadata, task context.
+type configLayer struct {
+	// Source names the layer in reports ("env", "flag", "context", "file <path>", ...)
+	Source string
+
+	// Prefix is prepended to keys in problem paths, e.g. "context."
+	Prefix string
+
+	Values map[string]any
+
+	// Strict rejects keys the config schema does not declare
+	Strict bool
+}
+
+// sourceOf describes where the layer's value for key came from
+func (l configLayer) sourceOf(key string) string {
+	switch l.Source {
+	case sourceEnv:
+		return sourceEnv + " " + configEnvName(key)
+	case sourceFlag:
+		return sourceFlag + " --" + configFlagName(key)
+	}
+	return l.Source
+}
+
+// deploymentLayers are the layers below the task context: the config file, the
+// environment and the command line. They are loaded once at startup.
+var deploymentLayers []configLayer
+
+// configEnvName returns the environment variable that sets key
+func configEnvName(key string) string {
+	return configEnvPrefix + strings.ToUpper(key)
+}
+
+// configFlagName returns the command line flag that sets key
+func configFlagName(key string) string {
+	return strings.ReplaceAll(key, "_", "-")
+}
+
+// LoadDeploymentConfig loads the per-deployment configuration layers: the YAML
+// or JSON config file at path (or $DEBUG_AGENT_CONFIG), DEBUG_AGENT_* variables
+// from environ, and the flags registered by RegisterConfigFlags. It fails when
+// any layer has unknown keys or invalid values.
+func LoadDeploymentConfig(path string, environ []string, flags *ConfigFlags) error {
+	var layers []configLayer
+
+	if path == "" {
+		path = lookupEnv(environ, configFileEnv)
+	}
+	var profiles map[string]map[string]any
+	if path != "" {
+		layer, fileProfiles, err := loadConfigFile(path)
+		if err != nil {
+			return err
+		}
+		layers = append(layers, layer)
+		profiles = fileProfiles
+	}
+
+	env, problems := envConfigLayer(environ)
+	if len(problems) > 0 {
+		return &ConfigError{Problems: problems}
+	}
+	layers = append(layers, env)
+
+	if flags != nil {
+		layers = append(layers, flags.layer())
+	}
+
+	// Resolve once so a broken deployment fails at startup rather than on every task
+	if _, err := resolveConfig(layers, profiles); err != nil {
+		return err
+	}
+
+	deploymentLayers = layers
+	deploymentProfiles = profiles
+	return nil
+}
+
+// loadConfigFile reads a YAML or JSON config file with the same keys as the task
+// context, plus a profiles section defining named profiles
+func loadConfigFile(path string) (configLayer, map[string]map[string]any, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return configLayer{}, nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	values := map[string]any{}
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return configLayer{}, nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	var profiles map[string]map[string]any
+	if section, ok := values["profiles"]; ok {
+		delete(values, "profiles")
+		var problems []ConfigProblem
+		if profiles, problems = loadProfiles(path+": profiles", section); len(problems) > 0 {
+			return configLayer{}, nil, &ConfigError{Problems: problems}
+		}
+	}
+
+	layer := configLayer{
+		Source: "file " + path,
+		Prefix: path + ": ",
+		Values: values,
+		Strict: true,
+	}
+	return layer, profiles, nil
+}
+
+// envConfigLayer decodes the DEBUG_AGENT_* variables of environ. Unknown
+// variables are reported so misspelled settings do not go unnoticed.
+func envConfigLayer(environ []string) (configLayer, []ConfigProblem) {
+	layer := configLayer{Source: sourceEnv, Prefix: configEnvPrefix, Values: map[string]any{}, Strict: true}
+
+	var problems []ConfigProblem
+	for _, entry := range environ {
+		name, text, _ := strings.Cut(entry, "=")
+		rest, ok := strings.CutPrefix(name, configEnvPrefix)
+		if !ok || name == configFileEnv {
+			continue
+		}
+
+		key := strings.ToLower(rest)
+		field, ok := lookupConfigField(key)
+		if !ok || field.TaskOnly {
+			message := "unknown configuration variable"
+			if suggestion := suggestConfigKey(key); suggestion != "" {
+				message += fmt.Sprintf(" (did you mean %s?)", configEnvName(suggestion))
+			}
+			problems = append(problems, ConfigProblem{Path: name, Message: message})
+			continue
+		}
+
+		value, fieldProblems := field.parseString(name, text)
+		if len(fieldProblems) > 0 {
+			problems = append(problems, fieldProblems...)
+			continue
+		}
+		layer.Values[key] = value
+	}
+
+	sort.Slice(problems, func(i, j int) bool { return problems[i].Path < problems[j].Path })
+	return layer, problems
+}
+
+// lookupEnv returns the value of name in environ
+func lookupEnv(environ []string, name string) string {
+	for _, entry := range environ {
+		if value, ok := strings.CutPrefix(entry, name+"="); ok {
+			return value
+		}
+	}
+	return ""
+}
+
+// ConfigFlags holds the configuration keys set on the command line
+type ConfigFlags struct {
+	flags []*configFlag
+}
+
+// RegisterConfigFlags defines a flag for every configuration key on fs, named
+// after the key with dashes (e.g. --test-timeout)
+func RegisterConfigFlags(fs *flag.FlagSet) *ConfigFlags {
+	flags := &ConfigFlags{}
+	for _, field := range configSchema {
+		if field.TaskOnly {
+			continue
+		}
+		f := &configFlag{field: field}
+		fs.Var(f, configFlagName(field.Key), field.Description)
+		flags.flags = append(flags.flags, f)
+	}
+	return flags
+}
+
+// layer returns the flags that were set as a configuration layer
+func (c *ConfigFlags) layer() configLayer {
+	layer := configLayer{Source: sourceFlag, Prefix: "--", Values: map[string]any{}, Strict: true}
+	for _, f := range c.flags {
+		if f.set {
+			layer.Values[f.field.Key] = f.value
+		}
+	}
+	return layer
+}
+
+// configFlag is a command line flag for one configuration key. Values are
+// checked against the config schema as they are parsed.
+type configFlag struct {
+	field configField
+	text  string
+	value any
+	set   bool
+}
+
+// String returns the flag's text value
+func (f *configFlag) String() string {
+	return f.text
+}
+
+// Set parses and checks the flag's value
+func (f *configFlag) Set(text string) error {
+	value, problems := f.field.parseString("", text)
+	if len(problems) > 0 {
+		return fmt.Errorf("%s", problems[0].Message)
+	}
+	f.text, f.value, f.set = text, value, true
+	return nil
+}
+
+// IsBoolFlag lets boolean keys be given without a value, e.g. --verbose
+func (f *configFlag) IsBoolFlag() bool {
+	return f.field.Types[0] == typeBoolean
+}
+
+// formatConfigText renders the effective configuration and the source of each
+// value for the text report
+func formatConfigText(cfg *DebugConfig) string {
+	var b strings.Builder
+	b.WriteString("\n=== Effective Configuration ===\n")
+	for _, v := range cfg.Effective() {
+		value, err := json.Marshal(v.Value)
+		if err != nil {
+			value = []byte(fmt.Sprint(v.Value))
+		}
+		fmt.Fprintf(&b, "  %s: %s (%s)\n", v.Key, value, v.Source)
+	}
+	return b.String()
+}
@@ -18,8 +18,36 @@ const (
 )
 
 func main() {
+	// Subcommands run instead of the agent
+	if len(os.Args) > 1 && os.Args[1] == validateMissionCommand {
+		os.Exit(runValidateMission(os.Args[2:], os.Stdout, os.Stderr))
+	}
+
+	// Parse command line flags
+	// Gibson CLI passes --port flag when starting agents
+	portFlag := flag.Int("port", 0, "Port to listen on (passed by Gibson CLI)")
+	configSchemaFlag := flag.Bool("config-schema", false, "Print the JSON Schema of the task context and exit")
+	configFileFlag := flag.String("config", "", "YAML or JSON config file with deployment defaults (or $"+configFileEnv+")")
+	configFlags := RegisterConfigFlags(flag.CommandLine)
+	flag.Parse()
+
+	if *configSchemaFlag {
+		doc, err := ConfigJSONSchema()
+		if err != nil {
+			log.Fatalf("Failed to build config schema: %v", err)
+		}
+		fmt.Println(string(doc))
+		return
+	}
+
 	fmt.Printf("Gibson Debug Agent v%s\n\n", agentVersion)
 
+	// Load the deployment configuration layers applied beneath each task's context:
+	// config file, DEBUG_AGENT_* environment variables, then command line flags
+	if err := LoadDeploymentConfig(*configFileFlag, os.Environ(), configFlags); err != nil {
+		log.Fatalf("Invalid deployment configuration: %v", err)
+	}
+
 	// Create the debug agent using SDK builder pattern
 	debugAgent, err := sdk.NewAgent(
 		// Basic metadata
@@ -71,11 +99,6 @@ func main() {
 		log.Fatalf("Failed to create debug agent: %v", err)
 	}
 
-	// Parse command line flags
-	// Gibson CLI passes --port flag when starting agents
-	portFlag := flag.Int("port", 0, "Port to listen on (passed by Gibson CLI)")
-	flag.Parse()
-
 	// Determine port: CLI flag > environment variable > default
 	port := 50051
 	if *portFlag > 0 {
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"embed"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/zero-day-ai/agents/debug/internal/workflow"
+)
+
+// validateMissionCommand is the subcommand that validates mission files instead
+// of serving the agent
+const validateMissionCommand = "validate-mission"
+
+// missionFiles holds the mission files shipped with the agent
+//
+//go:embed testdata/*-mission.yaml
+var missionFiles embed.FS
+
+// bundledMissions returns the mission files shipped with the agent
+func bundledMissions() []workflow.Mission {
+	names, _ := fs.Glob(missionFiles, "testdata/*.yaml")
+	missions := make([]workflow.Mission, 0, len(names))
+	for _, name := range names {
+		data, err := missionFiles.ReadFile(name)
+		if err != nil {
+			continue
+		}
+		missions = append(missions, workflow.Mission{Name: name, Data: data})
+	}
+	return missions
+}
+
+// missionOptions returns the validator options for missions running the debug
+// agent: its task contexts are checked against the config schema, and nodes may
+// also reference the other agents named
+func missionOptions(otherAgents []string) workflow.Options {
+	agents := map[string]workflow.ContextValidator{agentName: checkTaskContext}
+	for _, name := range otherAgents {
+		if name = strings.TrimSpace(name); name != "" && name != agentName {
+			agents[name] = nil
+		}
+	}
+	return workflow.Options{Agents: agents}
+}
+
+// checkTaskContext resolves a mission node's task context the way ParseConfig
+// does, so schema problems and invalid mode combinations are reported before
+// the mission runs
+func checkTaskContext(context map[string]any) []workflow.ContextProblem {
+	layer := configLayer{Source: sourceContext, Values: context, Strict: true}
+	_, err := resolveConfig([]configLayer{layer}, deploymentProfiles)
+	if err == nil {
+		return nil
+	}
+
+	var configErr *ConfigError
+	if !errors.As(err, &configErr) {
+		return []workflow.ContextProblem{{Message: err.Error()}}
+	}
+	problems := make([]workflow.ContextProblem, len(configErr.Problems))
+	for i, p := range configErr.Problems {
+		problems[i] = workflow.ContextProblem{Path: p.Path, Message: p.Message}
+	}
+	return problems
+}
+
+// runValidateMission validates the mission files named in args, printing each
+// problem as "file:line:column: message". It returns the exit code: 0 when
+// every file is valid, 1 when a file is invalid or unreadable, 2 on usage errors.
+func runValidateMission(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet(validateMissionCommand, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	otherAgents := flags.String("agents", "", "Comma separated names of other agents the missions may reference")
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: %s %s [--agents name,...] <mission.yaml>...\n", agentName, validateMissionCommand)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return 2
+	}
+
+	opts := missionOptions(strings.Split(*otherAgents, ","))
+	code := 0
+	for _, file := range flags.Args() {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			fmt.Fprintf(stderr, "%s: %v\n", file, err)
+			code = 1
+			continue
+		}
+
+		problems := workflow.Validate(data, opts)
+		if len(problems) == 0 {
+			fmt.Fprintf(stdout, "%s: ok\n", file)
+			continue
+		}
+		for _, p := range problems {
+			fmt.Fprintf(stdout, "%s:%s\n", file, p)
+		}
+		code = 1
+	}
+	return code
+}
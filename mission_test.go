@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/zero-day-ai/agents/debug/internal/workflow"
+)
+
+func TestCheckTaskContext(t *testing.T) {
+	tests := []struct {
+		name    string
+		context map[string]any
+		want    []workflow.ContextProblem
+	}{
+		{
+			name:    "valid",
+			context: map[string]any{"mode": "network-recon-analyze", "generate_intelligence": true, "goal": "analyze"},
+		},
+		{
+			name:    "unknown key",
+			context: map[string]any{"mode": "network-recon-discover", "demo_mode": false},
+			want:    []workflow.ContextProblem{{Path: "demo_mode", Message: "unknown key"}},
+		},
+		{
+			name:    "wrong type",
+			context: map[string]any{"verbose": "yes"},
+			want:    []workflow.ContextProblem{{Path: "verbose", Message: `expected boolean, got string "yes"`}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := checkTaskContext(tt.context)
+			if len(got) != len(tt.want) {
+				t.Fatalf("checkTaskContext() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i].Path != tt.want[i].Path || !strings.HasPrefix(got[i].Message, tt.want[i].Message) {
+					t.Errorf("problem %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestBundledMissions(t *testing.T) {
+	missions := bundledMissions()
+	if len(missions) == 0 {
+		t.Fatal("no bundled missions")
+	}
+	for _, mission := range missions {
+		if problems := workflow.Validate(mission.Data, missionOptions(nil)); len(problems) != 0 {
+			t.Errorf("%s: %v", mission.Name, problems)
+		}
+	}
+}
+
+func TestRunValidateMission(t *testing.T) {
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "valid.yaml")
+	invalid := filepath.Join(dir, "invalid.yaml")
+	writeFile(t, valid, "name: valid\nnodes:\n  - id: scan\n    type: agent\n    agent: debug-agent\n    task:\n      context:\n        mode: network-recon-scan\n")
+	writeFile(t, invalid, "name: invalid\nnodes:\n  - id: scan\n    type: agent\n    agent: debug-agent\n    task:\n      context:\n        mode: network-recon-scan\n        demo_mode: false\n")
+
+	tests := []struct {
+		name       string
+		args       []string
+		wantCode   int
+		wantOutput string
+	}{
+		{name: "valid", args: []string{valid}, wantCode: 0, wantOutput: valid + ": ok\n"},
+		{name: "demo_mode rejected", args: []string{invalid}, wantCode: 1, wantOutput: invalid + ":9:9: task context demo_mode: unknown key"},
+		{name: "other agent", args: []string{"--agents", "recon-agent", valid}, wantCode: 0, wantOutput: valid + ": ok\n"},
+		{name: "no files", args: nil, wantCode: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := runValidateMission(tt.args, &stdout, &stderr); code != tt.wantCode {
+				t.Errorf("exit code = %d, want %d (stderr %q)", code, tt.wantCode, stderr.String())
+			}
+			if !strings.HasPrefix(stdout.String(), tt.wantOutput) {
+				t.Errorf("output = %q, want prefix %q", stdout.String(), tt.wantOutput)
+			}
+		})
+	}
+}
+
+// writeFile writes a test fixture
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
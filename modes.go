@@ -0,0 +1,270 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/zero-day-ai/sdk/agent"
+
+	"github.com/zero-day-ai/agents/debug/internal/delegation"
+	"github.com/zero-day-ai/agents/debug/internal/intelligence"
+	"github.com/zero-day-ai/agents/debug/internal/recon"
+	"github.com/zero-day-ai/agents/debug/internal/runner"
+	"github.com/zero-day-ai/agents/debug/internal/summary"
+)
+
+// Mode selects what a task executes: the whole test suite, the test modules of
+// one targeted diagnostic, or a handler producing its own result
+type Mode string
+
+const (
+	// ModeFull runs every registered test module (default)
+	ModeFull Mode = "full"
+
+	// ModeSDK runs only the SDK test modules
+	ModeSDK Mode = "sdk"
+
+	// ModeFramework runs only the Framework test modules
+	ModeFramework Mode = "framework"
+
+	// ModeSingle runs only the tests listed in tests
+	ModeSingle Mode = "single"
+
+	// ModeNetworkRecon runs only the network reconnaissance module
+	ModeNetworkRecon Mode = "network-recon"
+
+	// ModeHealthCheck checks one infrastructure component (see DebugConfig.Component)
+	ModeHealthCheck Mode = "health-check"
+
+	// ModeMemoryTest exercises the working, mission and long-term memory tiers
+	ModeMemoryTest Mode = "memory-test"
+
+	// ModeToolTest lists tools and executes a safe one (ping localhost, echo, list)
+	ModeToolTest Mode = "tool-test"
+
+	// ModeGraphRAGTest stores, queries and traverses [DEBUG] graph data
+	ModeGraphRAGTest Mode = "graphrag-test"
+
+	// ModeLLMTest calls the LLM methods, or only DebugConfig.Method when set
+	ModeLLMTest Mode = "llm-test"
+
+	// ModeDelegationTest delegates a task to another debug agent
+	ModeDelegationTest Mode = "delegation-test"
+
+	// ModeFindingsTest submits a finding and reads it back
+	ModeFindingsTest Mode = "findings-test"
+
+	// ModeChild is the mode a delegated debug agent runs in: it echoes its task back
+	ModeChild Mode = "child"
+
+	// ModeSummary summarizes the results earlier modes published to memory
+	ModeSummary Mode = "summary"
+)
+
+// modeSpec describes how a mode executes and which mode-specific keys it accepts
+type modeSpec struct {
+	// selects reports whether a suite mode runs a module (nil for other modes)
+	selects func(module runner.TestModule) bool
+
+	// handle runs a mode that produces its own agent result instead of a suite
+	handle func(ctx context.Context, h agent.Harness, task agent.Task, cfg *DebugConfig) (agent.Result, error)
+
+	// phase is the working memory key a diagnostic publishes its counts under
+	// for summary mode (empty publishes per category)
+	phase string
+
+	// uses lists the mode-specific keys (component, method, prefix) the mode reads
+	uses []string
+
+	// requires lists the mode-specific keys that must be set
+	requires []string
+}
+
+// modeSpecificKeys are keys only some modes read. Setting one for a mode that
+// ignores it is reported as an error rather than silently dropped. The full
+// suite runs every health check and LLM method with the default prefix.
+var modeSpecificKeys = []string{"component", "method", "prefix"}
+
+// networkReconPhases maps the per-phase modes of the recon mission
+// (network-recon-<phase>) to the reconnaissance phase they run
+var networkReconPhases = map[string]recon.Phase{
+	"discover": recon.PhaseDiscover,
+	"probe":    recon.PhaseProbe,
+	"scan":     recon.PhaseScan,
+	"domain":   recon.PhaseDomain,
+}
+
+// modes maps every accepted mode to its spec
+var modes = buildModes()
+
+// buildModes returns the mode table
+func buildModes() map[Mode]modeSpec {
+	all := func(runner.TestModule) bool { return true }
+	category := func(c runner.Category) func(runner.TestModule) bool {
+		return func(m runner.TestModule) bool { return m.Category() == c }
+	}
+	module := func(name string) func(runner.TestModule) bool {
+		return func(m runner.TestModule) bool { return m.Name() == name }
+	}
+	table := map[Mode]modeSpec{
+		ModeFull:         {selects: all},
+		ModeSDK:          {selects: category(runner.CategorySDK)},
+		ModeFramework:    {selects: category(runner.CategoryFramework)},
+		ModeSingle:       {selects: all},
+		ModeNetworkRecon: {selects: module("network-recon")},
+
+		ModeHealthCheck:    {selects: module("health"), phase: "health_check", uses: []string{"component"}, requires: []string{"component"}},
+		ModeMemoryTest:     {selects: module("memory"), phase: "memory_test"},
+		ModeToolTest:       {selects: module("tools"), phase: "tools_test"},
+		ModeGraphRAGTest:   {selects: module("graphrag"), phase: "graphrag_test", uses: []string{"prefix"}},
+		ModeLLMTest:        {selects: module("llm"), phase: "llm_test", uses: []string{"method"}},
+		ModeDelegationTest: {selects: module("delegation"), phase: "delegation_test"},
+		ModeFindingsTest:   {selects: module("findings"), phase: "findings_test", uses: []string{"prefix"}},
+
+		ModeChild:   {handle: handleChild},
+		ModeSummary: {handle: handleSummary},
+	}
+	for name, phase := range networkReconPhases {
+		table[ModeNetworkRecon+Mode("-"+name)] = modeSpec{handle: reconPhase(phase)}
+	}
+	table[ModeNetworkRecon+"-analyze"] = modeSpec{handle: handleAnalyze}
+	return table
+}
+
+// modeNames returns every accepted mode, sorted
+func modeNames() []string {
+	names := make([]string, 0, len(modes))
+	for mode := range modes {
+		names = append(names, string(mode))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// suite reports whether the mode runs the test suite
+func (s modeSpec) suite() bool {
+	return s.selects != nil
+}
+
+// validateMode checks the mode and the mode-specific keys it needs or ignores
+func (c *DebugConfig) validateMode() error {
+	spec, ok := modes[c.Mode]
+	if !ok {
+		return fmt.Errorf("invalid mode: %s (must be one of: %s)", c.Mode, strings.Join(modeNames(), ", "))
+	}
+
+	set := map[string]bool{
+		"component": c.Component != "",
+		"method":    c.Method != "",
+		"prefix":    c.Prefix != "",
+	}
+	for _, key := range spec.requires {
+		if !set[key] {
+			return fmt.Errorf("mode %s requires %s", c.Mode, key)
+		}
+	}
+	for _, key := range modeSpecificKeys {
+		if set[key] && !slices.Contains(spec.uses, key) {
+			return fmt.Errorf("%s is not used by mode %s", key, c.Mode)
+		}
+	}
+
+	if c.Mode == ModeSingle && len(c.TargetTests) == 0 {
+		return fmt.Errorf("mode single requires tests")
+	}
+	if c.DryRun && !spec.suite() {
+		return fmt.Errorf("dry_run is only supported by test suite modes, not %s", c.Mode)
+	}
+	return nil
+}
+
+// executeMode runs a mode that produces its own agent result instead of a suite
+func executeMode(ctx context.Context, h agent.Harness, task agent.Task, cfg *DebugConfig, spec modeSpec) (agent.Result, error) {
+	h.Logger().Info("Executing mode", "mode", cfg.Mode)
+	return spec.handle(ctx, h, task, cfg)
+}
+
+// publishPhases stores a run's counts in working memory, where summary mode
+// reads them: a diagnostic under its mode's phase key, a suite run per category
+func publishPhases(ctx context.Context, h agent.Harness, spec modeSpec, suiteResult *runner.SuiteResult) {
+	mem := h.Memory()
+	if mem == nil || mem.Working() == nil {
+		return
+	}
+
+	phases := map[string]runner.CategorySummary{}
+	if spec.phase != "" {
+		phases[spec.phase] = runner.CategorySummary{
+			Total:          suiteResult.TotalTests(),
+			Passed:         suiteResult.TotalPassed(),
+			Failed:         suiteResult.TotalFailed(),
+			Skipped:        suiteResult.TotalSkipped(),
+			Errors:         suiteResult.TotalErrors(),
+			Flaky:          suiteResult.TotalFlaky(),
+			NotImplemented: suiteResult.TotalNotImplemented(),
+		}
+	} else {
+		if suiteResult.SDKSummary.Total > 0 {
+			phases["sdk_tests"] = suiteResult.SDKSummary
+		}
+		if suiteResult.FrameworkSummary.Total > 0 {
+			phases["framework_tests"] = suiteResult.FrameworkSummary
+		}
+	}
+
+	for phase, summary := range phases {
+		// Flaky tests eventually passed
+		counts := map[string]any{
+			"total":           summary.Total,
+			"passed":          summary.Passed + summary.Flaky,
+			"failed":          summary.Failed,
+			"skipped":         summary.Skipped,
+			"errors":          summary.Errors,
+			"not_implemented": summary.NotImplemented,
+		}
+		if err := mem.Working().Set(ctx, phase, counts); err != nil {
+			h.Logger().Warn("Failed to publish phase results", "phase", phase, "error", err)
+		}
+	}
+}
+
+// handleChild runs child mode, echoing the delegated task back to the parent
+func handleChild(ctx context.Context, h agent.Harness, task agent.Task, cfg *DebugConfig) (agent.Result, error) {
+	return delegation.ExecuteChild(ctx, h, task)
+}
+
+// handleSummary runs summary mode
+func handleSummary(ctx context.Context, h agent.Harness, task agent.Task, cfg *DebugConfig) (agent.Result, error) {
+	return summary.ExecuteSummary(ctx, h, &summary.Config{IncludeDetails: cfg.Verbose})
+}
+
+// reconPhase returns the handler of the network-recon-<phase> mode running phase
+func reconPhase(phase recon.Phase) func(ctx context.Context, h agent.Harness, task agent.Task, cfg *DebugConfig) (agent.Result, error) {
+	return func(ctx context.Context, h agent.Harness, task agent.Task, cfg *DebugConfig) (agent.Result, error) {
+		if !cfg.ShouldRunPhase(string(phase)) {
+			return skippedPhase(string(phase)), nil
+		}
+		return recon.ExecutePhase(ctx, h, phase, &recon.Config{Subnet: cfg.Subnet, Domains: cfg.Domains})
+	}
+}
+
+// handleAnalyze runs network-recon-analyze mode, generating intelligence from
+// the reconnaissance data unless generate_intelligence is off
+func handleAnalyze(ctx context.Context, h agent.Harness, task agent.Task, cfg *DebugConfig) (agent.Result, error) {
+	if !cfg.ShouldRunPhase("analyze") || !cfg.GenerateIntelligence {
+		return skippedPhase("analyze"), nil
+	}
+	return intelligence.ExecuteAnalyze(ctx, h)
+}
+
+// skippedPhase is the result of a reconnaissance phase the config skips
+func skippedPhase(phase string) agent.Result {
+	return agent.Result{
+		Status:   agent.StatusSuccess,
+		Output:   fmt.Sprintf("Phase %s skipped by configuration", phase),
+		Metadata: map[string]any{"phase": phase, "skipped": true},
+	}
+}
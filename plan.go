@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/zero-day-ai/sdk/agent"
+
+	"github.com/zero-day-ai/agents/debug/internal/runner"
+)
+
+// executeDryRun plans the suite and reports what would execute without running it
+func executeDryRun(ctx context.Context, testRunner *runner.Runner, cfg *DebugConfig, startTime time.Time) (agent.Result, error) {
+	plan, err := testRunner.Plan(ctx)
+	if err != nil {
+		return agent.Result{
+			Status: agent.StatusFailed,
+			Output: fmt.Sprintf("Planning error: %v", err),
+		}, nil
+	}
+
+	var output string
+	switch cfg.OutputFormat {
+	case OutputJSON:
+		output = formatPlanJSON(plan, cfg)
+	case OutputBoth:
+		output = formatPlanText(plan, cfg) + "\n\n--- JSON Output ---\n\n" + formatPlanJSON(plan, cfg)
+	default:
+		output = formatPlanText(plan, cfg)
+	}
+
+	return agent.Result{
+		Status: agent.StatusSuccess,
+		Output: output,
+		Metadata: map[string]any{
+			"dry_run":      true,
+			"duration":     time.Since(startTime).String(),
+			"total_tests":  len(plan.Tests),
+			"planned_run":  plan.Count(runner.PlanRun),
+			"planned_skip": plan.Count(runner.PlanSkip),
+			"planned_fail": plan.Count(runner.PlanFail),
+		},
+	}, nil
+}
+
+// formatPlanText creates human-readable plan output
+func formatPlanText(plan *runner.Plan, cfg *DebugConfig) string {
+	var b strings.Builder
+	b.WriteString("\n=== Debug Agent Execution Plan (dry run) ===\n")
+
+	b.WriteString("\n=== Preconditions ===\n")
+	for _, check := range plan.Checks {
+		status := "ok"
+		if !check.OK {
+			status = "FAIL"
+		}
+		fmt.Fprintf(&b, "[%s] %s: %s\n", status, check.Name, check.Message)
+	}
+
+	fmt.Fprintf(&b, "\n=== Planned Tests ===\nRun: %d, Skip: %d, Fail: %d\n",
+		plan.Count(runner.PlanRun), plan.Count(runner.PlanSkip), plan.Count(runner.PlanFail))
+
+	module := ""
+	for _, test := range plan.Tests {
+		if test.Module != module {
+			module = test.Module
+			fmt.Fprintf(&b, "\n%s (%s)\n", module, test.Category)
+		}
+
+		fmt.Fprintf(&b, "  [%s] %s (Req %s)", test.Action, test.Name, test.RequirementID)
+		if slices.Contains(test.Tags, runner.TagActive) {
+			b.WriteString(" [active]")
+		}
+		b.WriteString("\n")
+		if test.ID != test.Module {
+			fmt.Fprintf(&b, "    ID: %s\n", test.ID)
+		}
+		if test.Description != "" {
+			fmt.Fprintf(&b, "    Plan: %s\n", test.Description)
+		}
+		if len(test.Tools) > 0 {
+			fmt.Fprintf(&b, "    Tools: %s\n", strings.Join(test.Tools, ", "))
+		}
+		if test.Reason != "" {
+			fmt.Fprintf(&b, "    Reason: %s\n", test.Reason)
+		}
+	}
+
+	b.WriteString(formatConfigText(cfg))
+	return b.String()
+}
+
+// formatPlanJSON creates JSON plan output
+func formatPlanJSON(plan *runner.Plan, cfg *DebugConfig) string {
+	jsonBytes, err := json.MarshalIndent(map[string]any{
+		"dry_run": true,
+		"summary": map[string]any{
+			"total": len(plan.Tests),
+			"run":   plan.Count(runner.PlanRun),
+			"skip":  plan.Count(runner.PlanSkip),
+			"fail":  plan.Count(runner.PlanFail),
+		},
+		"plan":   plan,
+		"config": cfg.Effective(),
+	}, "", "  ")
+	if err != nil {
+		return fmt.Sprintf("Error formatting JSON: %v", err)
+	}
+
+	return string(jsonBytes)
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// defaultProfile is the profile used when none is selected. Its settings are
+// the values of DefaultConfig.
+const defaultProfile = "full"
+
+// builtinProfiles are the profiles available without a config file. Each maps
+// configuration keys to values, exactly as they would appear in a task context,
+// applied over the defaults. The task context can override any of them.
+var builtinProfiles = map[string]map[string]any{
+	// full runs every test, including active network scans
+	defaultProfile: {},
+
+	// smoke is a quick check that the SDK and framework respond: no active
+	// scans, no LLM calls, short timeouts
+	"smoke": {
+		"timeout":            "3m",
+		"category_timeout":   "2m",
+		"test_timeout":       "10s",
+		"retry_attempts":     1,
+		"skip_tests":         []any{"llm"},
+		"allow_active_scans": false,
+		"output_format":      "text",
+	},
+
+	// nightly runs everything with generous timeouts and a retry for flaky
+	// infrastructure, reporting JUnit for CI dashboards
+	"nightly": {
+		"timeout":            "30m",
+		"category_timeout":   "20m",
+		"test_timeout":       "30s",
+		"retry_attempts":     2,
+		"allow_active_scans": true,
+		"output_format":      "junit",
+	},
+}
+
+// deploymentProfiles are the user-defined profiles from the config file, loaded
+// once at startup. A user-defined profile replaces a built-in one of the same name.
+var deploymentProfiles map[string]map[string]any
+
+// loadProfiles checks the profiles section of a config file. Profiles take the
+// same keys as the task context, except profile itself.
+func loadProfiles(prefix string, raw any) (map[string]map[string]any, []ConfigProblem) {
+	section, ok := raw.(map[string]any)
+	if !ok {
+		return nil, []ConfigProblem{{Path: prefix, Message: "expected object mapping profile names to settings, got " + describeJSON(raw)}}
+	}
+
+	profiles := make(map[string]map[string]any, len(section))
+	var problems []ConfigProblem
+	for name, settings := range section {
+		path := prefix + "." + name
+		values, ok := settings.(map[string]any)
+		if !ok {
+			problems = append(problems, ConfigProblem{Path: path, Message: "expected object, got " + describeJSON(settings)})
+			continue
+		}
+		if _, ok := values["profile"]; ok {
+			problems = append(problems, ConfigProblem{Path: path + ".profile", Message: "a profile cannot select another profile"})
+			continue
+		}
+		problems = append(problems, checkConfigMap(path+".", values, true)...)
+		profiles[name] = values
+	}
+
+	sort.Slice(problems, func(i, j int) bool { return problems[i].Path < problems[j].Path })
+	return profiles, problems
+}
+
+// profileLayer returns the configuration layer of the named profile
+func profileLayer(name string, profiles map[string]map[string]any) (configLayer, error) {
+	values, ok := profiles[name]
+	if !ok {
+		values, ok = builtinProfiles[name]
+	}
+	if !ok {
+		return configLayer{}, fmt.Errorf("unknown profile %q (available: %s)", name, strings.Join(profileNames(profiles), ", "))
+	}
+	return configLayer{Source: "profile " + name, Prefix: "profiles." + name + ".", Values: values}, nil
+}
+
+// profileNames returns the names of the built-in and user-defined profiles, sorted
+func profileNames(profiles map[string]map[string]any) []string {
+	names := slices.Collect(maps.Keys(builtinProfiles))
+	for name := range profiles {
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// selectedProfile returns the profile named by the last layer that sets one
+func selectedProfile(layers []configLayer) string {
+	name := defaultProfile
+	for _, layer := range layers {
+		if profile, ok := layer.Values["profile"].(string); ok {
+			name = profile
+		}
+	}
+	return name
+}